@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/Gurkunwar/asyncflow/internal/api"
 	"github.com/Gurkunwar/asyncflow/internal/bot"
@@ -32,9 +33,20 @@ func main() {
 		log.Fatalf("❌ Failed to create Discord session: %v", err)
 	}
 
+	catchUpWindow := services.DefaultCatchUpWindow
+	if raw := os.Getenv("STANDUP_CATCHUP_WINDOW"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			catchUpWindow = parsed
+		} else {
+			log.Printf("⚠️ Invalid STANDUP_CATCHUP_WINDOW %q, using %s", raw, catchUpWindow)
+		}
+	}
+
 	standupSvc := &services.StandupService{
-		DB:      db,
-		Session: dg,
+		DB:            db,
+		Session:       dg,
+		CatchUpWindow: catchUpWindow,
+		Redis:         rdb,
 	}
 	userSvc := &services.UserService{DB: db}
 	pollSvc := services.NewPollService(db, dg)
@@ -42,10 +54,15 @@ func main() {
 	handler := bot.NewBotHandler(dg, rdb, db, standupSvc, pollSvc, userSvc)
 
 	standupSvc.TriggerFunc = handler.Standups.InitiateStandup
+	standupSvc.ReminderFunc = handler.Standups.SendReminder
 
 	dg.AddHandler(handler.OnInteraction)
+	dg.AddHandler(handler.Standups.OnDirectMessage)
 	dg.AddHandler(handler.Polls.OnVoteAdd)
     dg.AddHandler(handler.Polls.OnVoteRemove)
+	dg.AddHandler(handler.Standups.OnGuildMemberAdd)
+	dg.AddHandler(handler.Standups.OnGuildMemberUpdate)
+	dg.AddHandler(handler.Standups.OnGuildMemberRemove)
 
 	standupSvc.StartTimezoneWorker()
 
@@ -67,4 +84,6 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	<-stop
+
+	standupSvc.StopTimezoneWorker()
 }
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/api/dtos"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+// HandleGetBlockers lists the blockers of the caller's standups, open ones by default.
+// standup_id narrows it to one standup and status=resolved|all widens what is returned.
+func (s *Server) HandleGetBlockers(w http.ResponseWriter, r *http.Request) {
+	managerID := r.Context().Value(UserIDKey).(string)
+
+	standups, err := s.StandupService.GetUserManagedStandups(managerID)
+	if err != nil {
+		http.Error(w, "Failed to fetch standups", http.StatusInternalServerError)
+		return
+	}
+
+	if raw := r.URL.Query().Get("standup_id"); raw != "" {
+		standupID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid standup_id parameter", http.StatusBadRequest)
+			return
+		}
+		var only []models.Standup
+		for _, st := range standups {
+			if st.ID == uint(standupID) {
+				only = append(only, st)
+			}
+		}
+		if len(only) == 0 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		standups = only
+	}
+
+	status := models.BlockerOpen
+	switch r.URL.Query().Get("status") {
+	case "", models.BlockerOpen:
+	case models.BlockerResolved:
+		status = models.BlockerResolved
+	case "all":
+		status = ""
+	default:
+		http.Error(w, "status must be open, resolved or all", http.StatusBadRequest)
+		return
+	}
+
+	blockers, err := s.blockerDTOs(standups, status)
+	if err != nil {
+		http.Error(w, "Failed to fetch blockers", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(blockers)
+}
+
+// HandleResolveBlocker closes a blocker from the dashboard.
+func (s *Server) HandleResolveBlocker(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+	blockerID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Missing id parameter", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.StandupService.ResolveBlocker(uint(blockerID), userID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Blocker resolved"})
+}
+
+// openBlockerDTOs feeds the dashboard's blocker list.
+func (s *Server) openBlockerDTOs(standups []models.Standup) []dtos.BlockerDTO {
+	blockers, _ := s.blockerDTOs(standups, models.BlockerOpen)
+	return blockers
+}
+
+func (s *Server) blockerDTOs(standups []models.Standup, status string) ([]dtos.BlockerDTO, error) {
+	byID := make(map[uint]models.Standup, len(standups))
+	var standupIDs []uint
+	for _, st := range standups {
+		byID[st.ID] = st
+		standupIDs = append(standupIDs, st.ID)
+	}
+
+	blockers, err := s.StandupService.GetBlockers(standupIDs, status)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]dtos.BlockerDTO, 0, len(blockers))
+	for _, blocker := range blockers {
+		standup := byID[blocker.StandupID]
+		userName, avatar := s.lookupMember(standup.GuildID, blocker.UserID)
+		result = append(result, dtos.BlockerDTO{
+			ID:         blocker.ID,
+			UserID:     blocker.UserID,
+			User:       userName,
+			Avatar:     avatar,
+			Team:       standup.Name,
+			Task:       blocker.Text,
+			StandupID:  blocker.StandupID,
+			HistoryID:  blocker.HistoryID,
+			Status:     blocker.Status,
+			AgeHours:   int(blocker.Age(now).Hours()),
+			ResolvedAt: blocker.ResolvedAt,
+			CreatedAt:  blocker.CreatedAt,
+		})
+	}
+	return result, nil
+}
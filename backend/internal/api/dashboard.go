@@ -13,11 +13,13 @@ func (s *Server) HandleGetDashboardStats(w http.ResponseWriter, r *http.Request)
 	managerID := r.Context().Value(UserIDKey).(string)
 	var stats dtos.DashboardStatsDTO
 
-	s.DB.Model(&models.Standup{}).Where("manager_id = ?", managerID).Count(&stats.TotalTeams)
+	// Stats cover every standup the caller manages, co-manages or observes.
+	scope := s.StandupService.AccessibleStandups(managerID, models.AccessView)
+	s.DB.Model(&models.Standup{}).Where(scope).Count(&stats.TotalTeams)
 
 	s.DB.Table("standup_participants").
 		Joins("JOIN standups ON standups.id = standup_participants.standup_id").
-		Where("standups.manager_id = ?", managerID).
+		Where(scope).
 		Distinct("user_profile_id").
 		Count(&stats.TotalMembers)
 
@@ -34,7 +36,7 @@ func (s *Server) HandleGetDashboardStats(w http.ResponseWriter, r *http.Request)
 	s.DB.Table("standup_histories").
 		Select("date, count(*) as count").
 		Joins("JOIN standups ON standups.id = standup_histories.standup_id").
-		Where("standups.manager_id = ? AND standup_histories.date > ?", managerID, sevenDaysAgo).
+		Where(scope).Where("standup_histories.date > ?", sevenDaysAgo).
 		Group("date").
 		Scan(&dailyResults)
 
@@ -65,74 +67,51 @@ func (s *Server) HandleGetDashboardStats(w http.ResponseWriter, r *http.Request)
 	s.DB.Table("standup_histories").
 		Select("standups.name as team_name, count(standup_histories.id) as count").
 		Joins("JOIN standups ON standups.id = standup_histories.standup_id").
-		Where("standups.manager_id = ?", managerID).
+		Where(scope).
 		Group("standups.name").
 		Scan(&stats.BreakdownData)
 
-	// 4. Latest Updates (REMOVED EXTERNAL NETWORK CALLS)
-	var recentHistories []models.StandupHistory
-	s.DB.Preload("Standup").
-		Joins("JOIN standups ON standups.id = standup_histories.standup_id").
-		Where("standups.manager_id = ?", managerID).
-		Order("standup_histories.created_at desc").
-		Limit(15).
-		Find(&recentHistories)
-
-	for _, h := range recentHistories {
-		userName := "User " + h.UserID[len(h.UserID)-4:]
-		avatar := "0"
-
-		// Step 1: Check Local DB First (0ms latency)
-		var profile models.UserProfile
-		err := s.DB.Where("user_id = ?", h.UserID).First(&profile).Error
-
-		if err == nil {
-			// Found in DB!
-			userName = profile.Username
-			avatar = profile.Avatar
-		} else {
-			// Step 2: Not in DB? Check Discord Cache or API
-			member, err := s.Session.State.Member(h.Standup.GuildID, h.UserID)
-			if err != nil {
-				// If not in cache, hit the network (Slow, but only happens once)
-				member, _ = s.Session.GuildMember(h.Standup.GuildID, h.UserID)
-			}
-
-			if member != nil && member.User != nil {
-				userName = member.User.Username
-				if member.User.Avatar != "" {
-					avatar = member.User.Avatar
-				} else {
-					avatar = "0"
-				}
-
-				// Step 3: SAVE to DB so it's 0ms next time (Upsert)
-				s.DB.Where(models.UserProfile{UserID: h.UserID}).
-					Assign(models.UserProfile{
-						Username: userName,
-						Avatar:   avatar,
-					}).FirstOrCreate(&models.UserProfile{})
-			}
-		}
+	// 4. Open blockers across the manager's standups
+	standups, _ := s.StandupService.GetUserManagedStandups(managerID)
+	stats.Blockers = s.openBlockerDTOs(standups)
 
-		taskText := "Submitted daily report"
-		if len(h.Answers) > 0 && h.Answers[0] != "" {
-			taskText = h.Answers[0]
-		}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
 
-		stats.Blockers = append(stats.Blockers, dtos.BlockerDTO{
-			ID:        h.ID,
-			UserID:    h.UserID,
-			User:      userName,
-			Avatar:    avatar,
-			Team:      h.Standup.Name,
-			Task:      taskText,
-			CreatedAt: h.CreatedAt,
-		})
+// lookupMember returns a user's display name and avatar hash, preferring the local
+// profile and falling back to Discord, whose answer is saved for next time.
+func (s *Server) lookupMember(guildID, userID string) (string, string) {
+	userName := "User " + userID[len(userID)-4:]
+	avatar := "0"
+
+	// Step 1: Check Local DB First (0ms latency)
+	var profile models.UserProfile
+	if err := s.DB.Where("user_id = ?", userID).First(&profile).Error; err == nil {
+		return profile.Username, profile.Avatar
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(stats)
+	// Step 2: Not in DB? Check Discord Cache or API
+	member, err := s.Session.State.Member(guildID, userID)
+	if err != nil {
+		// If not in cache, hit the network (Slow, but only happens once)
+		member, _ = s.Session.GuildMember(guildID, userID)
+	}
+
+	if member != nil && member.User != nil {
+		userName = member.User.Username
+		if member.User.Avatar != "" {
+			avatar = member.User.Avatar
+		}
+
+		// Step 3: SAVE to DB so it's 0ms next time (Upsert)
+		s.DB.Where(models.UserProfile{UserID: userID}).
+			Assign(models.UserProfile{
+				Username: userName,
+				Avatar:   avatar,
+			}).FirstOrCreate(&models.UserProfile{})
+	}
+	return userName, avatar
 }
 
 func (s *Server) HandleGetPollStats(w http.ResponseWriter, r *http.Request) {
@@ -40,9 +40,18 @@ type HistoryDTO struct {
 	Avatar    string   `json:"avatar"`
 	Date      string   `json:"date"`
 	Answers   []string `json:"answers"`
+	Entries   []HistoryEntryDTO `json:"entries"`
+	Edited    bool     `json:"edited"`
 	CreatedAt string   `json:"created_at"`
 }
 
+// HistoryEntryDTO is one answer of a report next to the question it answered that day.
+type HistoryEntryDTO struct {
+	QuestionID string `json:"question_id"`
+	Question   string `json:"question"`
+	Answer     string `json:"answer"`
+}
+
 func (s *Server) HandleGetUserGuilds(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(UserIDKey).(string)
 
@@ -2,14 +2,20 @@ package dtos
 
 import "time"
 
+// BlockerDTO is an open or resolved blocker; Task holds what was reported.
 type BlockerDTO struct {
-	ID     uint   `json:"id"`
-	UserID string `json:"user_id"`
-	User   string `json:"user"`
-	Avatar string `json:"avatar"`
-	Team   string `json:"team"`
-	Task   string `json:"task"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         uint       `json:"id"`
+	UserID     string     `json:"user_id"`
+	User       string     `json:"user"`
+	Avatar     string     `json:"avatar"`
+	Team       string     `json:"team"`
+	Task       string     `json:"task"`
+	StandupID  uint       `json:"standup_id"`
+	HistoryID  uint       `json:"history_id"`
+	Status     string     `json:"status"`
+	AgeHours   int        `json:"age_hours"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
 
 type TeamBreakdown struct {
@@ -8,4 +8,10 @@ type StandupDTO struct {
 	ChannelName     string `json:"channel_name"`
 	ReportChannelID string `json:"report_channel_id"`
 	CreatorName     string `json:"creator_name"`
+	// Role is the caller's role on the standup, empty when they only see it as a server admin.
+	Role string `json:"role"`
+	// Paused is set while the standup is on hold; ResumeOn is empty when it waits for a manager.
+	Paused      bool   `json:"paused"`
+	PauseReason string `json:"pause_reason"`
+	ResumeOn    string `json:"resume_on"`
 }
\ No newline at end of file
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+)
+
+// managesGuild reports whether the user manages or co-manages at least one standup in the
+// guild, which is what lets them edit the guild's shared holiday calendars.
+func (s *Server) managesGuild(userID, guildID string) bool {
+	return s.StandupService.ManagesGuild(userID, guildID)
+}
+
+func (s *Server) HandleGetHolidayCalendars(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(string)
+	guildID := r.URL.Query().Get("guild_id")
+
+	if !s.managesGuild(userID, guildID) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	calendars, err := s.StandupService.GetHolidayCalendars(guildID)
+	if err != nil {
+		http.Error(w, "Failed to fetch holiday calendars", http.StatusInternalServerError)
+		return
+	}
+	if calendars == nil {
+		calendars = []models.HolidayCalendar{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(calendars)
+}
+
+func (s *Server) HandleCreateHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+
+	var payload struct {
+		GuildID   string           `json:"guild_id"`
+		Name      string           `json:"name"`
+		Holidays  []models.Holiday `json:"holidays"`
+		StandupID uint             `json:"standup_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if !s.managesGuild(userID, payload.GuildID) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !s.authorizeCalendarStandup(w, r, payload.GuildID, payload.StandupID) {
+		return
+	}
+
+	calendar, added, err := s.StandupService.CreateHolidayCalendar(payload.GuildID, payload.Name, payload.Holidays)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.respondHolidayCalendar(w, calendar, added, payload.StandupID)
+}
+
+// HandleImportHolidayCalendar accepts a multipart upload with a "file" (.ics) part plus
+// guild_id, name and an optional standup_id to attach the calendar to.
+func (s *Server) HandleImportHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxCalendarBytes+64*1024)
+	if err := r.ParseMultipartForm(services.MaxCalendarBytes); err != nil {
+		http.Error(w, "Calendar upload is invalid or larger than 1 MB", http.StatusBadRequest)
+		return
+	}
+
+	guildID := r.FormValue("guild_id")
+	if !s.managesGuild(userID, guildID) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	standupID, _ := strconv.ParseUint(r.FormValue("standup_id"), 10, 32)
+	if !s.authorizeCalendarStandup(w, r, guildID, uint(standupID)) {
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	name := r.FormValue("name")
+	if name == "" {
+		name = header.Filename
+		if ext := filepath.Ext(name); strings.EqualFold(ext, ".ics") {
+			name = strings.TrimSuffix(name, ext)
+		}
+	}
+
+	calendar, added, err := s.StandupService.ImportHolidayCalendar(guildID, name, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.respondHolidayCalendar(w, calendar, added, uint(standupID))
+}
+
+// authorizeCalendarStandup checks, before a new calendar for the guild is saved, that
+// the caller may attach it to the standup they asked for, if any.
+func (s *Server) authorizeCalendarStandup(w http.ResponseWriter, r *http.Request, guildID string,
+	standupID uint) bool {
+
+	if standupID == 0 {
+		return true
+	}
+	standup, ok := s.authorizeStandup(r, standupID, models.AccessManage)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	if standup.GuildID != guildID {
+		http.Error(w, "holiday calendar belongs to a different server", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (s *Server) respondHolidayCalendar(w http.ResponseWriter, calendar *models.HolidayCalendar, added int,
+	standupID uint) {
+
+	if standupID != 0 {
+		if err := s.StandupService.AttachHolidayCalendar(standupID, calendar.ID); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"calendar": calendar,
+		"added":    added,
+	})
+}
+
+func (s *Server) HandleAttachHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	s.handleHolidayAttachment(w, r, true)
+}
+
+func (s *Server) HandleDetachHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	s.handleHolidayAttachment(w, r, false)
+}
+
+func (s *Server) handleHolidayAttachment(w http.ResponseWriter, r *http.Request, attach bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		StandupID  uint `json:"standup_id"`
+		CalendarID uint `json:"calendar_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.authorizeStandup(r, payload.StandupID, models.AccessManage); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var err error
+	if attach {
+		err = s.StandupService.AttachHolidayCalendar(payload.StandupID, payload.CalendarID)
+	} else {
+		err = s.StandupService.DetachHolidayCalendar(payload.StandupID, payload.CalendarID)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Holiday calendars updated"})
+}
+
+func (s *Server) HandleDeleteHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	calendar, ok := s.authorizedCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	var err error
+	if date != "" {
+		err = s.StandupService.RemoveHoliday(calendar.ID, date)
+	} else {
+		err = s.StandupService.DeleteHolidayCalendar(calendar.ID)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Deleted successfully"})
+}
+
+func (s *Server) authorizedCalendar(w http.ResponseWriter, r *http.Request) (*models.HolidayCalendar, bool) {
+	userID := r.Context().Value(UserIDKey).(string)
+	calendarID, _ := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+
+	var calendar models.HolidayCalendar
+	if err := s.DB.First(&calendar, calendarID).Error; err != nil {
+		http.Error(w, "Holiday calendar not found", http.StatusNotFound)
+		return nil, false
+	}
+	if !s.managesGuild(userID, calendar.GuildID) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	return &calendar, true
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+func (s *Server) HandleGetOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(string)
+
+	entries, err := s.StandupService.GetOutOfOffice(userID)
+	if err != nil {
+		http.Error(w, "Failed to fetch out of office days", http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []models.OutOfOffice{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (s *Server) HandleCreateOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+
+	var payload struct {
+		StandupID uint   `json:"standup_id"`
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		Reason    string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.EndDate == "" {
+		payload.EndDate = payload.StartDate
+	}
+
+	entry, err := s.StandupService.AddOutOfOffice(models.OutOfOffice{
+		UserID:    userID,
+		StandupID: payload.StandupID,
+		StartDate: payload.StartDate,
+		EndDate:   payload.EndDate,
+		Reason:    payload.Reason,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (s *Server) HandleDeleteOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(string)
+	entryID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.StandupService.DeleteOutOfOffice(userID, uint(entryID)); err != nil {
+		http.Error(w, "Entry not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Deleted successfully"})
+}
@@ -42,8 +42,34 @@ func (s *Server) Routes() {
 	http.HandleFunc("/api/standups/delete", AuthMiddleware(s.HandleDeleteStandup))
 	http.HandleFunc("/api/standups/add-member", AuthMiddleware(s.HandleAddStandupMember))
 	http.HandleFunc("/api/standups/remove-member", AuthMiddleware(s.HandleRemoveStandupMember))
+	http.HandleFunc("/api/standups/participant-schedule", AuthMiddleware(s.HandleSetParticipantSchedule))
+	http.HandleFunc("/api/standups/roles", AuthMiddleware(s.HandleGetStandupRoles))
+	http.HandleFunc("/api/standups/roles/grant", AuthMiddleware(s.HandleGrantStandupRole))
+	http.HandleFunc("/api/standups/roles/revoke", AuthMiddleware(s.HandleRevokeStandupRole))
+	http.HandleFunc("/api/standups/transfer", AuthMiddleware(s.HandleTransferStandup))
+	http.HandleFunc("/api/standups/resync", AuthMiddleware(s.HandleResyncStandupRoles))
+	http.HandleFunc("/api/standups/pause", AuthMiddleware(s.HandlePauseStandup))
+	http.HandleFunc("/api/standups/resume", AuthMiddleware(s.HandleResumeStandup))
 	http.HandleFunc("/api/standups/get", AuthMiddleware(s.HandleGetStandup))
 	http.HandleFunc("/api/standups/history", AuthMiddleware(s.HandleGetStandupHistory))
+	http.HandleFunc("/api/standups/question-stats", AuthMiddleware(s.HandleGetQuestionStats))
+	http.HandleFunc("/api/standups/history/update", AuthMiddleware(s.HandleUpdateReport))
+	http.HandleFunc("/api/standups/history/delete", AuthMiddleware(s.HandleRetractReport))
+
+	http.HandleFunc("/api/blockers", AuthMiddleware(s.HandleGetBlockers))
+	http.HandleFunc("/api/blockers/resolve", AuthMiddleware(s.HandleResolveBlocker))
+
+	http.HandleFunc("/api/templates", AuthMiddleware(s.HandleGetTemplates))
+	http.HandleFunc("/api/templates/create", AuthMiddleware(s.HandleCreateTemplate))
+	http.HandleFunc("/api/templates/update", AuthMiddleware(s.HandleUpdateTemplate))
+	http.HandleFunc("/api/templates/delete", AuthMiddleware(s.HandleDeleteTemplate))
+
+	http.HandleFunc("/api/holidays", AuthMiddleware(s.HandleGetHolidayCalendars))
+	http.HandleFunc("/api/holidays/create", AuthMiddleware(s.HandleCreateHolidayCalendar))
+	http.HandleFunc("/api/holidays/import", AuthMiddleware(s.HandleImportHolidayCalendar))
+	http.HandleFunc("/api/holidays/attach", AuthMiddleware(s.HandleAttachHolidayCalendar))
+	http.HandleFunc("/api/holidays/detach", AuthMiddleware(s.HandleDetachHolidayCalendar))
+	http.HandleFunc("/api/holidays/delete", AuthMiddleware(s.HandleDeleteHolidayCalendar))
 
 	http.HandleFunc("/api/managed-polls", AuthMiddleware(s.HandleGetManagedPolls))
 	http.HandleFunc("/api/polls/get", AuthMiddleware(s.HandleGetPoll))
@@ -55,6 +81,10 @@ func (s *Server) Routes() {
 
 	http.HandleFunc("/api/user/settings/get", AuthMiddleware(s.HandleGetUserSettings))
     http.HandleFunc("/api/user/settings/update", AuthMiddleware(s.HandleUpdateUserSettings))
+
+	http.HandleFunc("/api/user/ooo", AuthMiddleware(s.HandleGetOutOfOffice))
+	http.HandleFunc("/api/user/ooo/create", AuthMiddleware(s.HandleCreateOutOfOffice))
+	http.HandleFunc("/api/user/ooo/delete", AuthMiddleware(s.HandleDeleteOutOfOffice))
 }
 
 func (s *Server) Start(port string) {
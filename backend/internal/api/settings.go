@@ -24,8 +24,9 @@ func (s *Server) HandleGetUserSettings(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"timezone": profile.Timezone,
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"timezone":  profile.Timezone,
+		"chat_mode": profile.ChatMode,
 	})
 }
 
@@ -38,7 +39,8 @@ func (s *Server) HandleUpdateUserSettings(w http.ResponseWriter, r *http.Request
 	userID := r.Context().Value(UserIDKey).(string)
 
 	var payload struct {
-		Timezone string `json:"timezone"`
+		Timezone *string `json:"timezone"`
+		ChatMode *bool   `json:"chat_mode"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
@@ -46,10 +48,23 @@ func (s *Server) HandleUpdateUserSettings(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := s.DB.Model(&models.UserProfile{}).Where("user_id = ?", userID).Update("timezone", payload.Timezone).Error; err != nil {
-		http.Error(w, "Failed to update timezone", http.StatusInternalServerError)
+	updates := map[string]interface{}{}
+	if payload.Timezone != nil {
+		updates["timezone"] = *payload.Timezone
+	}
+	if payload.ChatMode != nil {
+		updates["chat_mode"] = *payload.ChatMode
+	}
+	if len(updates) == 0 {
+		http.Error(w, "Nothing to update", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.DB.Model(&models.UserProfile{}).Where("user_id = ?", userID).Updates(updates).Error; err != nil {
+		http.Error(w, "Failed to update settings", http.StatusInternalServerError)
 		return
 	}
+	s.StandupService.InvalidateUser(userID)
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
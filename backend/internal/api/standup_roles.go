@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+)
+
+// StandupRoleDTO is one person who helps run a standup, as listed on the dashboard.
+type StandupRoleDTO struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	Avatar   string `json:"avatar"`
+	Role     string `json:"role"`
+}
+
+// isServerAdmin reports whether the user administers the server that owns the channel.
+func (s *Server) isServerAdmin(userID, channelID string) bool {
+	if channelID == "" {
+		return false
+	}
+	p, err := s.Session.UserChannelPermissions(userID, channelID)
+	return err == nil && services.IsServerAdmin(p)
+}
+
+// authorizeStandup loads a standup and checks the caller has the needed access to it. It
+// is what every standup endpoint checks permissions with.
+func (s *Server) authorizeStandup(r *http.Request, standupID uint, need models.Access) (*models.Standup, bool) {
+	userID := r.Context().Value(UserIDKey).(string)
+
+	var standup models.Standup
+	if err := s.DB.First(&standup, standupID).Error; err != nil {
+		return nil, false
+	}
+	admin := s.isServerAdmin(userID, standup.ReportChannelID)
+	if !s.StandupService.Authorize(standup, userID, admin, need) {
+		return nil, false
+	}
+	return &standup, true
+}
+
+// HandleGetStandupRoles lists the standup's manager, co-managers and observers.
+func (s *Server) HandleGetStandupRoles(w http.ResponseWriter, r *http.Request) {
+	standupID, err := strconv.ParseUint(r.URL.Query().Get("standup_id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Missing standup_id parameter", http.StatusBadRequest)
+		return
+	}
+	standup, ok := s.authorizeStandup(r, uint(standupID), models.AccessView)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	members, err := s.StandupService.GetMembers(standup.ID)
+	if err != nil {
+		http.Error(w, "Failed to fetch roles", http.StatusInternalServerError)
+		return
+	}
+
+	var response []StandupRoleDTO
+	if standup.ManagerID != "" {
+		members = append([]models.StandupMember{{UserID: standup.ManagerID, Role: models.RoleManager}}, members...)
+	}
+	for _, member := range members {
+		userName, avatar := s.lookupMember(standup.GuildID, member.UserID)
+		response = append(response, StandupRoleDTO{
+			UserID:   member.UserID,
+			UserName: userName,
+			Avatar:   avatar,
+			Role:     member.Role,
+		})
+	}
+	if response == nil {
+		response = []StandupRoleDTO{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// HandleGrantStandupRole makes a user a co-manager or observer of a standup.
+func (s *Server) HandleGrantStandupRole(w http.ResponseWriter, r *http.Request) {
+	s.changeStandupRole(w, r, "grant")
+}
+
+// HandleRevokeStandupRole takes a user's co-manager or observer role away.
+func (s *Server) HandleRevokeStandupRole(w http.ResponseWriter, r *http.Request) {
+	s.changeStandupRole(w, r, "revoke")
+}
+
+// HandleTransferStandup hands a standup over to a new manager.
+func (s *Server) HandleTransferStandup(w http.ResponseWriter, r *http.Request) {
+	s.changeStandupRole(w, r, "transfer")
+}
+
+func (s *Server) changeStandupRole(w http.ResponseWriter, r *http.Request, action string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		StandupID uint   `json:"standup_id"`
+		UserID    string `json:"user_id"`
+		Role      string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	need := models.AccessManage
+	if action == "transfer" {
+		need = models.AccessOwn
+	}
+	standup, ok := s.authorizeStandup(r, payload.StandupID, need)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	callerID := r.Context().Value(UserIDKey).(string)
+	admin := s.isServerAdmin(callerID, standup.ReportChannelID)
+
+	var err error
+	switch action {
+	case "grant":
+		err = s.StandupService.GrantRole(*standup, callerID, admin, payload.UserID, payload.Role)
+	case "revoke":
+		err = s.StandupService.RevokeRole(*standup, callerID, admin, payload.UserID)
+	case "transfer":
+		err = s.StandupService.TransferOwnership(*standup, callerID, admin, payload.UserID)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Roles updated"})
+}
@@ -5,9 +5,12 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Gurkunwar/asyncflow/internal/api/dtos"
 	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -40,23 +43,24 @@ func (s *Server) HandleGetManagedStandups(dg *discordgo.Session) http.HandlerFun
 
 		var adminGuildIDs []string
 		for _, gc := range combos {
-			if gc.ReportChannelID == "" {
-				continue
-			}
-			p, err := s.Session.UserChannelPermissions(managerID, gc.ReportChannelID)
-			if err == nil && (p&discordgo.PermissionAdministrator != 0 ||
-				p&discordgo.PermissionManageGuild != 0) {
+			if s.isServerAdmin(managerID, gc.ReportChannelID) {
 				adminGuildIDs = append(adminGuildIDs, gc.GuildID)
 			}
 		}
 
+		// "Mine" are the standups the caller runs; otherwise observed ones are listed too.
+		scope := s.StandupService.AccessibleStandups(managerID, models.AccessView)
+		if onlyMe {
+			scope = s.StandupService.AccessibleStandups(managerID, models.AccessManage)
+		}
+
 		query := s.DB.Model(&models.Standup{}).Order("id desc")
 
 		if guildFilter != "" && guildFilter != "All" {
 			query = query.Where("guild_id = ?", guildFilter)
 
 			if onlyMe {
-				query = query.Where("manager_id = ?", managerID)
+				query = query.Where(scope)
 			} else {
 				isAdminOfSelected := false
 				for _, id := range adminGuildIDs {
@@ -67,19 +71,17 @@ func (s *Server) HandleGetManagedStandups(dg *discordgo.Session) http.HandlerFun
 				}
 
 				if !isAdminOfSelected {
-					query = query.Where("manager_id = ?", managerID)
+					query = query.Where(scope)
 				}
 			}
 		} else {
 			if onlyMe {
-				query = query.Where("manager_id = ?", managerID)
+				query = query.Where(scope)
 			} else {
 				if len(adminGuildIDs) > 0 {
-					query = query.Where(
-						s.DB.Where("manager_id = ?", managerID).Or("guild_id IN ?", adminGuildIDs),
-					)
+					query = query.Where(s.DB.Where(scope).Or("guild_id IN ?", adminGuildIDs))
 				} else {
-					query = query.Where("manager_id = ?", managerID)
+					query = query.Where(scope)
 				}
 			}
 		}
@@ -116,6 +118,10 @@ func (s *Server) HandleGetManagedStandups(dg *discordgo.Session) http.HandlerFun
 				ChannelName:     cName,
 				ReportChannelID: st.ReportChannelID,
 				CreatorName:     creatorName,
+				Role:            s.StandupService.Role(st, managerID),
+				Paused:          st.IsPaused(),
+				PauseReason:     st.PauseReason,
+				ResumeOn:        st.ResumeOn,
 			})
 		}
 		if response == nil {
@@ -141,12 +147,27 @@ func (s *Server) HandleCreateStandup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var payload struct {
-		Name            string   `json:"name"`
-		Time            string   `json:"time"`
-		Days            string   `json:"days"`
-		GuildID         string   `json:"guild_id"`
-		ReportChannelID string   `json:"report_channel_id"`
-		Questions       []string `json:"questions"`
+		Name            string                `json:"name"`
+		Time            string                `json:"time"`
+		Days            string                `json:"days"`
+		GuildID         string                `json:"guild_id"`
+		ReportChannelID string                `json:"report_channel_id"`
+		Questions       []string              `json:"questions"`
+		QuestionSpecs   []models.QuestionSpec `json:"question_specs"`
+		ReminderOffsets []int64               `json:"reminder_offsets"`
+		MaxReminders    int                   `json:"max_reminders"`
+		CutoffTime      string                `json:"cutoff_time"`
+		ReportMode      string                `json:"report_mode"`
+		DigestTime      string                `json:"digest_time"`
+		DailyThread     bool                  `json:"daily_thread"`
+		ScheduleType    string                `json:"schedule_type"`
+		IntervalWeeks   int                   `json:"interval_weeks"`
+		AnchorDate      string                `json:"anchor_date"`
+		MonthWeek       int                   `json:"month_week"`
+		CronExpr        string                `json:"cron_expr"`
+		Timezone        string                `json:"timezone"`
+		SyncRoleIDs     []string              `json:"sync_role_ids"`
+		Template        string                `json:"template"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
@@ -164,15 +185,52 @@ func (s *Server) HandleCreateStandup(w http.ResponseWriter, r *http.Request) {
 		ReportChannelID: payload.ReportChannelID,
 		ManagerID:       managerID,
 		Questions:       payload.Questions,
+		QuestionSpecs:   payload.QuestionSpecs,
+		ReminderOffsets: payload.ReminderOffsets,
+		MaxReminders:    payload.MaxReminders,
+		CutoffTime:      payload.CutoffTime,
+		ReportMode:      payload.ReportMode,
+		DigestTime:      payload.DigestTime,
+		DailyThread:     payload.DailyThread,
+		ScheduleType:    payload.ScheduleType,
+		IntervalWeeks:   payload.IntervalWeeks,
+		AnchorDate:      payload.AnchorDate,
+		MonthWeek:       payload.MonthWeek,
+		CronExpr:        payload.CronExpr,
+		Timezone:        payload.Timezone,
+	}
+
+	// A template fills in whatever the payload leaves out.
+	if payload.Template != "" {
+		tmpl, err := s.StandupService.FindTemplate(payload.GuildID, managerID, payload.Template)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tmpl.Apply(&standup)
+		if standup.ScheduleType == models.ScheduleInterval && standup.AnchorDate == "" {
+			standup.AnchorDate = time.Now().UTC().Format("2006-01-02")
+		}
 	}
 
 	createdStandup, err := s.StandupService.CreateStandup(standup)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if services.IsValidationError(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	s.StandupService.AddMemberToStandup(managerID, createdStandup.ID)
+	s.StandupService.AddMemberToStandup(managerID, createdStandup.ID, models.StandupParticipant{})
+
+	if len(payload.SyncRoleIDs) > 0 {
+		if _, _, err := s.StandupService.SetSyncRoles(*createdStandup, payload.SyncRoleIDs); err != nil {
+			http.Error(w, "Standup created, but syncing its roles failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{
@@ -187,12 +245,27 @@ func (s *Server) HandleUpdateStandup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var payload struct {
-		ID              uint     `json:"id"`
-		Name            string   `json:"name"`
-		Time            string   `json:"time"`
-		Days            string   `json:"days"`
-		ReportChannelID string   `json:"report_channel_id"`
-		Questions       []string `json:"questions"`
+		ID              uint                   `json:"id"`
+		Name            string                 `json:"name"`
+		Time            string                 `json:"time"`
+		Days            string                 `json:"days"`
+		ReportChannelID string                 `json:"report_channel_id"`
+		Questions       []string               `json:"questions"`
+		QuestionIDs     []string               `json:"question_ids"`
+		QuestionSpecs   *[]models.QuestionSpec `json:"question_specs"`
+		ReminderOffsets *[]int64               `json:"reminder_offsets"`
+		MaxReminders    *int                   `json:"max_reminders"`
+		CutoffTime      *string                `json:"cutoff_time"`
+		ReportMode      *string                `json:"report_mode"`
+		DigestTime      *string                `json:"digest_time"`
+		DailyThread     *bool                  `json:"daily_thread"`
+		ScheduleType    *string                `json:"schedule_type"`
+		IntervalWeeks   *int                   `json:"interval_weeks"`
+		AnchorDate      *string                `json:"anchor_date"`
+		MonthWeek       *int                   `json:"month_week"`
+		CronExpr        *string                `json:"cron_expr"`
+		Timezone        *string                `json:"timezone"`
+		SyncRoleIDs     *[]string              `json:"sync_role_ids"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
@@ -200,25 +273,89 @@ func (s *Server) HandleUpdateStandup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	managerID := r.Context().Value(UserIDKey).(string)
-
-	var standup models.Standup
-	if err := s.DB.Where("id = ? AND manager_id = ?", payload.ID, managerID).First(&standup).Error; err != nil {
+	authorized, ok := s.authorizeStandup(r, payload.ID, models.AccessManage)
+	if !ok {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
+	standup := *authorized
 
 	standup.Name = payload.Name
 	standup.Time = payload.Time
 	standup.Days = payload.Days
 	standup.ReportChannelID = payload.ReportChannelID
-	standup.Questions = payload.Questions
+	origins := questionOrigins(standup, payload.Questions, payload.QuestionIDs)
+	if payload.QuestionSpecs != nil {
+		// Specs sent without IDs keep the ID of the question they replace.
+		specs := *payload.QuestionSpecs
+		if err := services.CheckQuestionLinks(standup, payload.Questions, specs, origins); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for i, old := range origins {
+			if i < len(specs) && specs[i].ID == "" && old >= 0 {
+				specs[i].ID = standup.QuestionSpec(old).ID
+			}
+		}
+		standup.QuestionSpecs = specs
+		standup.Questions = payload.Questions
+	} else {
+		// Clients that only send question text keep each question's answer type.
+		services.RemapQuestions(&standup, payload.Questions, origins)
+	}
+	if payload.ReminderOffsets != nil {
+		standup.ReminderOffsets = *payload.ReminderOffsets
+	}
+	if payload.MaxReminders != nil {
+		standup.MaxReminders = *payload.MaxReminders
+	}
+	if payload.CutoffTime != nil {
+		standup.CutoffTime = *payload.CutoffTime
+	}
+	if payload.ReportMode != nil {
+		standup.ReportMode = *payload.ReportMode
+	}
+	if payload.DigestTime != nil {
+		standup.DigestTime = *payload.DigestTime
+	}
+	if payload.DailyThread != nil {
+		standup.DailyThread = *payload.DailyThread
+	}
+	if payload.ScheduleType != nil {
+		standup.ScheduleType = *payload.ScheduleType
+	}
+	if payload.IntervalWeeks != nil {
+		standup.IntervalWeeks = *payload.IntervalWeeks
+	}
+	if payload.AnchorDate != nil {
+		standup.AnchorDate = *payload.AnchorDate
+	}
+	if payload.MonthWeek != nil {
+		standup.MonthWeek = *payload.MonthWeek
+	}
+	if payload.CronExpr != nil {
+		standup.CronExpr = *payload.CronExpr
+	}
+	if payload.Timezone != nil {
+		standup.Timezone = *payload.Timezone
+	}
 
 	if err := s.StandupService.UpdateStandup(standup); err != nil {
-		http.Error(w, "Failed to update", http.StatusInternalServerError)
+		if services.IsValidationError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to update: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	if payload.SyncRoleIDs != nil {
+		if _, _, err := s.StandupService.SetSyncRoles(standup, *payload.SyncRoleIDs); err != nil {
+			http.Error(w, "Failed to sync roles: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
 		"message": "Standup updated successfully!",
@@ -228,11 +365,8 @@ func (s *Server) HandleUpdateStandup(w http.ResponseWriter, r *http.Request) {
 func (s *Server) HandleDeleteStandup(w http.ResponseWriter, r *http.Request) {
 	standupIDStr := r.URL.Query().Get("id")
 	standupID, _ := strconv.ParseUint(standupIDStr, 10, 32)
-	managerID := r.Context().Value(UserIDKey).(string)
-
-	if err := s.DB.Where("id = ? AND manager_id = ?", standupID, managerID).
-		First(&models.Standup{}).Error; err != nil {
 
+	if _, ok := s.authorizeStandup(r, uint(standupID), models.AccessOwn); !ok {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
@@ -247,11 +381,16 @@ func (s *Server) HandleDeleteStandup(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) HandleGetStandupHistory(w http.ResponseWriter, r *http.Request) {
-	standupID := r.URL.Query().Get("standup_id")
-	if standupID == "" {
+	standupID, err := strconv.ParseUint(r.URL.Query().Get("standup_id"), 10, 32)
+	if err != nil {
 		http.Error(w, "Missing standup_id parameter", http.StatusBadRequest)
 		return
 	}
+	standup, ok := s.authorizeStandup(r, uint(standupID), models.AccessView)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
 	var histories []models.StandupHistory
 	if err := s.DB.Where("standup_id = ?", standupID).
@@ -281,7 +420,9 @@ func (s *Server) HandleGetStandupHistory(w http.ResponseWriter, r *http.Request)
 			UserName:  userName,
 			Avatar:    profile.Avatar,
 			Date:      h.Date,
-			Answers:   h.Answers,
+			Answers:   h.AlignedAnswers(*standup),
+			Entries:   historyEntries(*standup, h),
+			Edited:    h.EditedAt != nil,
 			CreatedAt: h.CreatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
@@ -303,15 +444,22 @@ func (s *Server) HandleAddStandupMember(w http.ResponseWriter, r *http.Request)
 	var reqBody struct {
 		StandupID uint   `json:"standup_id"`
 		UserID    string `json:"user_id"`
+		Time      string `json:"time"`
+		Days      string `json:"days"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
 		http.Error(w, "Invalid Payload", http.StatusBadRequest)
 		return
 	}
+	if _, ok := s.authorizeStandup(r, reqBody.StandupID, models.AccessManage); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
-	if err := s.StandupService.AddMemberToStandup(reqBody.UserID, reqBody.StandupID); err != nil {
-		http.Error(w, "Database error", http.StatusInternalServerError)
+	override := models.StandupParticipant{Time: reqBody.Time, Days: reqBody.Days}
+	if err := s.StandupService.AddMemberToStandup(reqBody.UserID, reqBody.StandupID, override); err != nil {
+		http.Error(w, "Failed to add member: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -333,6 +481,10 @@ func (s *Server) HandleRemoveStandupMember(w http.ResponseWriter, r *http.Reques
 		http.Error(w, "Invalid payload", http.StatusBadRequest)
 		return
 	}
+	if _, ok := s.authorizeStandup(r, reqBody.StandupID, models.AccessManage); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
 	if err := s.StandupService.RemoveMemberFromStandup(reqBody.UserID, reqBody.StandupID); err != nil {
 		http.Error(w, "Failed to remove member", http.StatusInternalServerError)
@@ -343,12 +495,108 @@ func (s *Server) HandleRemoveStandupMember(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(map[string]string{"message": "Member removed successfully"})
 }
 
+// HandleResyncStandupRoles reconciles a standup's members with its synced Discord roles.
+func (s *Server) HandleResyncStandupRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	standupID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Missing id", http.StatusBadRequest)
+		return
+	}
+	standup, ok := s.authorizeStandup(r, uint(standupID), models.AccessManage)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	added, removed, err := s.StandupService.ResyncStandupRoles(*standup)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"added": added, "removed": removed})
+}
+
+// HandlePauseStandup puts a standup on hold, optionally until resume_on (YYYY-MM-DD).
+func (s *Server) HandlePauseStandup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqBody struct {
+		StandupID uint   `json:"standup_id"`
+		Reason    string `json:"reason"`
+		ResumeOn  string `json:"resume_on"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	standup, ok := s.authorizeStandup(r, reqBody.StandupID, models.AccessManage)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+	if err := s.StandupService.PauseStandup(*standup, userID, reqBody.Reason, reqBody.ResumeOn); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Standup paused"})
+}
+
+// HandleResumeStandup takes a paused standup off hold and lets its members know.
+func (s *Server) HandleResumeStandup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqBody struct {
+		StandupID uint `json:"standup_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	standup, ok := s.authorizeStandup(r, reqBody.StandupID, models.AccessManage)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+	if err := s.StandupService.ResumeStandup(*standup, userID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Standup resumed"})
+}
+
 func (s *Server) HandleGetStandup(w http.ResponseWriter, r *http.Request) {
-	standupID := r.URL.Query().Get("id")
-	if standupID == "" {
+	standupID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
 		http.Error(w, "Missing id", http.StatusBadRequest)
 		return
 	}
+	if _, ok := s.authorizeStandup(r, uint(standupID), models.AccessView); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
 	var standup models.Standup
 	if err := s.DB.Preload("Participants").First(&standup, standupID).Error; err != nil {
@@ -359,3 +607,189 @@ func (s *Server) HandleGetStandup(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(standup)
 }
+
+// HandleSetParticipantSchedule sets a member's own trigger time and days. Managers can set
+// it for anyone in their standup; members only for themselves.
+func (s *Server) HandleSetParticipantSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqBody struct {
+		StandupID uint   `json:"standup_id"`
+		UserID    string `json:"user_id"`
+		Time      string `json:"time"`
+		Days      string `json:"days"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	callerID := r.Context().Value(UserIDKey).(string)
+	if reqBody.UserID == "" {
+		reqBody.UserID = callerID
+	}
+	if reqBody.UserID != callerID {
+		if _, ok := s.authorizeStandup(r, reqBody.StandupID, models.AccessManage); !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	override := models.StandupParticipant{Time: reqBody.Time, Days: reqBody.Days}
+	if err := s.StandupService.SetParticipantOverride(reqBody.StandupID, reqBody.UserID, override); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Schedule updated successfully"})
+}
+
+// questionOrigins finds where each of the new questions was in the standup's current
+// list, or -1 for new ones. ids holds the ID each question was loaded with, empty for
+// added questions, so a reworded question keeps its settings and its past answers.
+// Clients that send no IDs have their questions matched by text instead.
+func questionOrigins(standup models.Standup, questions, ids []string) []int {
+	from := make([]int, len(questions))
+	taken := make(map[int]bool, len(questions))
+	for i, q := range questions {
+		from[i] = -1
+		old, ok := -1, false
+		if len(ids) == len(questions) {
+			if ids[i] != "" {
+				old, ok = standup.QuestionPosition(ids[i])
+			}
+		} else {
+			for j, existing := range standup.Questions {
+				if existing == q {
+					old, ok = j, true
+					break
+				}
+			}
+		}
+		// A question copied in the form keeps its ID only once.
+		if ok && !taken[old] {
+			from[i] = old
+			taken[old] = true
+		}
+	}
+	return from
+}
+
+// HandleGetQuestionStats aggregates typed answers (choices, yes/no, scales) for a standup
+// over the last `days` days, 30 by default.
+func (s *Server) HandleGetQuestionStats(w http.ResponseWriter, r *http.Request) {
+	standupID, err := strconv.ParseUint(r.URL.Query().Get("standup_id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Missing standup_id parameter", http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.authorizeStandup(r, uint(standupID), models.AccessView); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	days := 30
+	if d, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && d > 0 && d <= 365 {
+		days = d
+	}
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -days)
+
+	stats, err := s.StandupService.QuestionStats(uint(standupID), from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		http.Error(w, "Failed to fetch question stats", http.StatusInternalServerError)
+		return
+	}
+	if stats == nil {
+		stats = []services.QuestionStat{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// historyEntries pairs each answer of a report with the question as it was asked that day.
+func historyEntries(standup models.Standup, h models.StandupHistory) []HistoryEntryDTO {
+	entries := make([]HistoryEntryDTO, len(h.Answers))
+	for i, answer := range h.Answers {
+		question := h.AskedQuestion(standup, i)
+		entries[i] = HistoryEntryDTO{QuestionID: question.ID, Question: question.Text, Answer: answer}
+	}
+	return entries
+}
+
+// HandleUpdateReport lets a member correct a report they submitted. Answers are laid out
+// by current question position, like the history endpoint returns them; follow-ups that
+// weren't asked stay unasked, and answers to since-removed questions are kept as they were.
+func (s *Server) HandleUpdateReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		ID      uint     `json:"id"`
+		Answers []string `json:"answers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+	history, err := s.StandupService.GetOwnReport(payload.ID, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var standup models.Standup
+	s.DB.First(&standup, history.StandupID)
+
+	answers := make([]string, len(history.Answers))
+	for i := range answers {
+		answers[i] = history.Answers[i]
+		if q, ok := history.CurrentIndex(standup, i); ok && q < len(payload.Answers) {
+			answers[i] = strings.TrimSpace(payload.Answers[q])
+		}
+	}
+
+	// The answers still belong to the questions asked that day.
+	var questions []models.AskedQuestion
+	if len(history.Questions) == len(history.Answers) {
+		questions = history.Questions
+	}
+	if err := s.StandupService.UpdateReport(history, answers, history.Asked, questions); err != nil {
+		if services.IsValidationError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to update report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Report updated successfully"})
+}
+
+// HandleRetractReport deletes a member's own report and the message posted for it.
+func (s *Server) HandleRetractReport(w http.ResponseWriter, r *http.Request) {
+	historyID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+	if err := s.StandupService.RetractReport(uint(historyID), userID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Report retracted"})
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+)
+
+// HandleGetTemplates lists the built-in templates, the guild's and the caller's own, the
+// same library /create-standup offers.
+func (s *Server) HandleGetTemplates(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(string)
+	guildID := r.URL.Query().Get("guild_id")
+
+	if guildID != "" {
+		if _, err := s.Session.GuildMember(guildID, userID); err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	templates, err := s.StandupService.GetTemplates(guildID, userID)
+	if err != nil {
+		http.Error(w, "Failed to fetch templates", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+// HandleCreateTemplate saves a guild or personal template, either from the settings in
+// the payload or copied from the standup given by standup_id.
+func (s *Server) HandleCreateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.Context().Value(UserIDKey).(string)
+
+	var payload struct {
+		models.StandupTemplate
+		StandupID uint `json:"standup_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	tmpl := payload.StandupTemplate
+	if payload.StandupID != 0 {
+		standup, ok := s.authorizeStandup(r, payload.StandupID, models.AccessView)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		tmpl = services.TemplateFromStandup(*standup)
+		tmpl.Name = payload.Name
+		tmpl.Description = payload.Description
+		tmpl.Scope = payload.Scope
+		tmpl.GuildID = standup.GuildID
+	}
+	tmpl.ID = 0
+	tmpl.OwnerID = userID
+	tmpl.CreatedBy = userID
+
+	if !s.StandupService.CanEditTemplate(tmpl, userID, false) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	saved, err := s.StandupService.SaveTemplate(tmpl)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(saved)
+}
+
+// HandleUpdateTemplate replaces a stored template's settings. Its scope and owner stay
+// as they are.
+func (s *Server) HandleUpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload models.StandupTemplate
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	existing, ok := s.authorizedTemplate(r, payload.ID)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	payload.Scope = existing.Scope
+	payload.GuildID = existing.GuildID
+	payload.OwnerID = existing.OwnerID
+	payload.CreatedBy = existing.CreatedBy
+	payload.CreatedAt = existing.CreatedAt
+
+	saved, err := s.StandupService.SaveTemplate(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
+
+func (s *Server) HandleDeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Missing id", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := s.authorizedTemplate(r, uint(templateID)); !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.StandupService.DeleteTemplate(uint(templateID)); err != nil {
+		http.Error(w, "Failed to delete template", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Template deleted"})
+}
+
+// authorizedTemplate loads a stored template the caller may edit.
+func (s *Server) authorizedTemplate(r *http.Request, templateID uint) (*models.StandupTemplate, bool) {
+	userID := r.Context().Value(UserIDKey).(string)
+
+	var tmpl models.StandupTemplate
+	if err := s.DB.First(&tmpl, templateID).Error; err != nil {
+		return nil, false
+	}
+	if !s.StandupService.CanEditTemplate(tmpl, userID, false) {
+		return nil, false
+	}
+	return &tmpl, true
+}
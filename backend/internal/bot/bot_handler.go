@@ -59,8 +59,16 @@ func (h *BotHanlder) OnInteraction(session *discordgo.Session, intr *discordgo.I
 			h.handleHelp(session, intr)
 		case "timezone":
 			h.sendTimezoneMenu(session, intr, 0)
+		case "answer-mode":
+			h.handleAnswerMode(session, intr)
 		case "delete-my-data":
 			h.handleDeleteMyData(session, intr)
+		case "ooo":
+			h.handleOutOfOffice(session, intr)
+		}
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		if intr.ApplicationCommandData().Name == "ooo" {
+			h.handleOutOfOfficeAutocomplete(session, intr)
 		}
 	case discordgo.InteractionMessageComponent:
 		if intr.MessageComponentData().CustomID == "select_tz" {
@@ -78,6 +86,7 @@ func NewSession() (*discordgo.Session, error) {
 
 	dg.Identify.Intents = discordgo.IntentsGuilds |
 		discordgo.IntentsGuildMessages |
+		discordgo.IntentsGuildMembers |
 		discordgo.IntentDirectMessages |
 		discordgo.IntentGuildMessagePolls
 	return dg, nil
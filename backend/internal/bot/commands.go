@@ -5,6 +5,8 @@ import (
 )
 
 var adminPerms int64 = discordgo.PermissionAdministrator
+var zeroValue float64 = 0
+var oneValue float64 = 1
 
 var Commands = []*discordgo.ApplicationCommand{
 	{
@@ -23,11 +25,83 @@ var Commands = []*discordgo.ApplicationCommand{
 		Name:        "timezone",
 		Description: "Set your local timezone for standup reminders",
 	},
+	{
+		Name:        "answer-mode",
+		Description: "Choose how you answer standups: forms and buttons, or plain DM replies",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "mode",
+				Description: "How questions are asked",
+				Required:    true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Forms and buttons", Value: "form"},
+					{Name: "Chat (reply to DMs)", Value: "chat"},
+				},
+			},
+		},
+	},
+	{
+		Name:        "ooo",
+		Description: "Manage your out-of-office days so standups don't ping you",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "add",
+				Description: "Register days you'll be away",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "start",
+						Description: "First day away (YYYY-MM-DD)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "end",
+						Description: "Last day away (YYYY-MM-DD, defaults to the start date)",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "Only pause this standup (defaults to all of them)",
+						Required:     false,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "reason",
+						Description: "Optional note, e.g. Vacation",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "Show your upcoming out-of-office days",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "cancel",
+				Description: "Remove an out-of-office entry",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "id",
+						Description: "The entry ID shown by /ooo list",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
 	{
 		Name:                     "create-standup",
 		Description:              "Create a new team standup (Admin only)",
 		DefaultMemberPermissions: &adminPerms,
-		Options: []*discordgo.ApplicationCommandOption{
+		Options: append([]*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "name",
@@ -52,13 +126,25 @@ var Commands = []*discordgo.ApplicationCommand{
 				Description: "Time to trigger standup (HH:MM in 24h format, e.g. 09:30)",
 				Required:    false,
 			},
-		},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "timezone",
+				Description: "Fire at this time in one zone for everyone (e.g. Europe/London) instead of each user's",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "template",
+				Description:  "Start from a template's questions and schedule (default: Daily Scrum)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		}, cadenceOptions()...),
 	},
 	{
-		Name:                     "edit-standup",
-		Description:              "Edit an existing standup team (Admin only)",
-		DefaultMemberPermissions: &adminPerms,
-		Options: []*discordgo.ApplicationCommandOption{
+		Name:        "edit-standup",
+		Description: "Edit an existing standup team (Managers only)",
+		Options: append([]*discordgo.ApplicationCommandOption{
 			{
 				Type:         discordgo.ApplicationCommandOptionString,
 				Name:         "standup_name",
@@ -84,12 +170,165 @@ var Commands = []*discordgo.ApplicationCommand{
 				Description: "Comma-separated days (e.g. Monday,Tuesday,Wednesday,Thursday,Friday)",
 				Required:    false,
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reminders",
+				Description: "Nudge people who haven't submitted after these delays (e.g. 1h,3h) or 'off'",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "max_reminders",
+				Description: "Maximum number of nudges per day (default: one per delay)",
+				Required:    false,
+				MinValue:    &zeroValue,
+				MaxValue:    10,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "deadline",
+				Description: "Post a missing-reports summary at this time (HH:MM, standup's or manager's zone) or 'off'",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "report_mode",
+				Description: "Post each update as it arrives, or one combined digest per day",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Individual posts", Value: "individual"},
+					{Name: "Daily digest", Value: "digest"},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "digest_time",
+				Description: "When to publish the daily digest (HH:MM, standup's or manager's timezone)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "daily_thread",
+				Description: "Post each day's individual updates in a thread of their own",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "timezone",
+				Description: "Fire at one instant in this zone (e.g. Europe/London), or 'local' for each user's",
+				Required:    false,
+			},
+		}, cadenceOptions()...),
+	},
+	{
+		Name:        "holidays",
+		Description: "Manage the holiday calendars a standup skips (Managers only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "import",
+				Description: "Import holidays from an .ics file and attach them to a standup",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup that should skip these days",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Name:        "file",
+						Description: "An iCalendar (.ics) file",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "calendar",
+						Description: "Calendar name (defaults to the file name)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "add",
+				Description: "Add one day (or a range) off by hand",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup that should skip this day",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "date",
+						Description: "The day off (YYYY-MM-DD)",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "end_date",
+						Description: "Last day off for a range (YYYY-MM-DD)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "label",
+						Description: "What the holiday is called, e.g. Diwali",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "calendar",
+						Description:  "Calendar to add it to (defaults to '<standup> Holidays')",
+						Required:     false,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "Show the calendars and upcoming holidays of a standup",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "detach",
+				Description: "Stop a standup from skipping a calendar's holidays",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "calendar",
+						Description:  "The calendar to detach",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
 		},
 	},
 	{
-		Name:                     "delete-standup",
-		Description:              "Permanently delete an existing standup team (Admin only)",
-		DefaultMemberPermissions: &adminPerms,
+		Name:        "delete-standup",
+		Description: "Permanently delete an existing standup team (Manager only)",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:         discordgo.ApplicationCommandOptionString,
@@ -101,9 +340,46 @@ var Commands = []*discordgo.ApplicationCommand{
 		},
 	},
 	{
-		Name:                     "standup-info",
-		Description:              "View all settings, members, and questions for a standup",
-		DefaultMemberPermissions: &adminPerms,
+		Name:        "pause-standup",
+		Description: "Put a standup on hold, e.g. for an offsite or a code freeze (Managers only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "standup_name",
+				Description:  "The standup to pause",
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reason",
+				Description: "Why it is paused, shown in /standup-info",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "resume_on",
+				Description: "Resume automatically on this date (YYYY-MM-DD)",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "resume-standup",
+		Description: "Resume a paused standup and let its members know (Managers only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "standup_name",
+				Description:  "The standup to resume",
+				Required:     true,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "standup-info",
+		Description: "View all settings, members, and questions for a standup",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:         discordgo.ApplicationCommandOptionString,
@@ -115,9 +391,8 @@ var Commands = []*discordgo.ApplicationCommand{
 		},
 	},
 	{
-		Name:                     "add-member",
-		Description:              "Add a user to an existing standup (Admin Only)",
-		DefaultMemberPermissions: &adminPerms,
+		Name:        "add-member",
+		Description: "Add a user to an existing standup (Managers only)",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionUser,
@@ -132,12 +407,54 @@ var Commands = []*discordgo.ApplicationCommand{
 				Required:     true,
 				Autocomplete: true,
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "time",
+				Description: "Their own trigger time instead of the standup's (HH:MM, their timezone)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "days",
+				Description: "Their own active days instead of the standup's (e.g. Mon,Wed,Fri)",
+				Required:    false,
+			},
 		},
 	},
 	{
-		Name:                     "remove-member",
-		Description:              "Remove a user from an existing standup (Admin Only)",
-		DefaultMemberPermissions: &adminPerms,
+		Name:        "set-schedule",
+		Description: "Use your own trigger time or days for a standup (managers can set it for members)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "standup_name",
+				Description:  "The standup",
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "time",
+				Description: "Trigger time (HH:MM in your timezone) or 'default'",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "days",
+				Description: "Active days (e.g. Mon,Wed,Fri) or 'default'",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Member to change (managers only, defaults to you)",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "remove-member",
+		Description: "Remove a user from an existing standup (Managers only)",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionUser,
@@ -154,6 +471,230 @@ var Commands = []*discordgo.ApplicationCommand{
 			},
 		},
 	},
+	{
+		Name:        "standup-role",
+		Description: "Share the running of a standup with co-managers and observers",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "grant",
+				Description: "Make someone a co-manager or observer of a standup",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "Who gets the role",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "role",
+						Description: "Co-managers can run the standup; observers can read its reports",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Co-manager", Value: "co_manager"},
+							{Name: "Observer", Value: "observer"},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "revoke",
+				Description: "Take away someone's co-manager or observer role",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "Whose role to revoke",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "transfer",
+				Description: "Hand the standup over to a new manager (you stay on as co-manager)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "The new manager",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "template",
+		Description: "Reusable standup setups: questions, schedule, days and report mode",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "List the built-in, server and personal templates",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show what a template sets up",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "template",
+						Description:  "The template",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "save",
+				Description: "Save a standup's setup as a template (replaces one with the same name)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup to copy",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "Template name",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "scope",
+						Description: "Who can use it (default: just you)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Just me", Value: "personal"},
+							{Name: "Everyone in this server", Value: "guild"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "description",
+						Description: "What the template is for",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "delete",
+				Description: "Delete a server or personal template",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "template",
+						Description:  "The template",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "role-sync",
+		Description: "Keep a standup's members in sync with a Discord role",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "add",
+				Description: "Add everyone with a role to the standup, now and whenever they get it",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "The role to sync",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove",
+				Description: "Stop syncing a role (members it added are removed unless another synced role keeps them)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionRole,
+						Name:        "role",
+						Description: "The role to stop syncing",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "now",
+				Description: "Resync the standup's members with its roles right away",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "standup_name",
+						Description:  "The standup",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "blockers",
+		Description: "List open blockers reported in your standups",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "standup_name",
+				Description:  "Only show this standup's blockers",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
 	{
 		Name:        "history",
 		Description: "View past standup reports",
@@ -300,4 +841,54 @@ var Commands = []*discordgo.ApplicationCommand{
             },
         },
     },
-}
\ No newline at end of file
+}
+// cadenceOptions are shared by /create-standup and /edit-standup.
+func cadenceOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "cadence",
+			Description: "How often the standup runs",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Every week on the active days", Value: "weekly"},
+				{Name: "Every N weeks (e.g. biweekly)", Value: "interval"},
+				{Name: "Once a month (e.g. first Monday)", Value: "monthly"},
+				{Name: "Cron expression", Value: "cron"},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "every_weeks",
+			Description: "For 'Every N weeks': how many weeks apart (2 = biweekly)",
+			Required:    false,
+			MinValue:    &oneValue,
+			MaxValue:    52,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "anchor_date",
+			Description: "For 'Every N weeks': a date in a week it runs (YYYY-MM-DD, default this week)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "month_week",
+			Description: "For 'Once a month': which occurrence of the active day",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "First", Value: 1},
+				{Name: "Second", Value: 2},
+				{Name: "Third", Value: 3},
+				{Name: "Fourth", Value: 4},
+				{Name: "Last", Value: -1},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "cron",
+			Description: "For 'Cron expression': e.g. '0 9 1-7 * MON' (minute hour dom month dow)",
+			Required:    false,
+		},
+	}
+}
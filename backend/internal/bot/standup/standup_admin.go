@@ -1,18 +1,22 @@
 package standup
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
 	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
 	"github.com/bwmarrin/discordgo"
 )
 
 func (h *StandupHandler) fetchAuthorizedStandup(session *discordgo.Session,
 	intr *discordgo.InteractionCreate,
-	standupName string) (*models.Standup, bool) {
+	standupName string, need models.Access) (*models.Standup, bool) {
 
 	var standup models.Standup
 	if err := h.DB.Where("guild_id = ? AND name = ?", intr.GuildID, standupName).
@@ -22,16 +26,34 @@ func (h *StandupHandler) fetchAuthorizedStandup(session *discordgo.Session,
 		return nil, false
 	}
 
-	userID := utils.ExtractUserID(intr)
-	if standup.ManagerID != userID && !utils.IsServerAdmin(intr) {
-		utils.RespondWithError(session, intr.Interaction,
-			"⛔ Only the manager who created this standup, or a Server Admin, can manage it.")
+	if !h.authorizeStandup(session, intr, standup, need) {
 		return nil, false
 	}
-
 	return &standup, true
 }
 
+// authorizeStandup checks that the caller has the needed access to the standup, and tells
+// them why not when they don't.
+func (h *StandupHandler) authorizeStandup(session *discordgo.Session, intr *discordgo.InteractionCreate,
+	standup models.Standup, need models.Access) bool {
+
+	if h.StandupService.Authorize(standup, utils.ExtractUserID(intr), utils.IsServerAdmin(intr), need) {
+		return true
+	}
+
+	msg := "⛔ You're not part of this standup."
+	switch need {
+	case models.AccessOwn:
+		msg = "⛔ Only the standup's manager, or a Server Admin, can do that."
+	case models.AccessManage:
+		msg = "⛔ Only the standup's manager and co-managers, or a Server Admin, can manage it."
+	case models.AccessView:
+		msg = "⛔ Only the standup's managers and observers, or a Server Admin, can see that."
+	}
+	utils.RespondWithError(session, intr.Interaction, msg)
+	return false
+}
+
 func generateDaysMenu(standupID uint, activeDaysStr string) discordgo.MessageComponent {
 	if activeDaysStr == "" {
 		activeDaysStr = "Monday,Tuesday,Wednesday,Thursday,Friday"
@@ -62,6 +84,170 @@ func generateDaysMenu(standupID uint, activeDaysStr string) discordgo.MessageCom
 	}
 }
 
+func parseClockTime(raw string) (string, bool) {
+	var hTime, mTime int
+	if _, err := fmt.Sscanf(raw, "%d:%d", &hTime, &mTime); err != nil ||
+		hTime < 0 || hTime > 23 || mTime < 0 || mTime > 59 {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hTime, mTime), true
+}
+
+func formatCutoff(standup models.Standup) string {
+	if standup.CutoffTime == "" {
+		return "Off"
+	}
+	return fmt.Sprintf("%s (%s)", standup.CutoffTime, standupZoneLabel(standup))
+}
+
+// standupZoneLabel names the clock standup-wide times such as the deadline follow.
+func standupZoneLabel(standup models.Standup) string {
+	if standup.Timezone != "" {
+		return standup.Timezone
+	}
+	return "Manager's timezone"
+}
+
+// parseStandupTimezone accepts an IANA zone name, or 'local'/'off' to go back to firing
+// in each participant's own timezone.
+func parseStandupTimezone(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	switch strings.ToLower(raw) {
+	case "", "local", "off", "none":
+		return "", true
+	}
+	if _, err := time.LoadLocation(raw); err != nil {
+		return "", false
+	}
+	return raw, true
+}
+
+// applyCadenceOptions copies the cadence options of /create-standup or /edit-standup onto
+// the standup, filling in sensible defaults for the parts that were left out. It reports
+// whether anything changed; the service validates the result.
+func applyCadenceOptions(standup *models.Standup,
+	optMap map[string]*discordgo.ApplicationCommandInteractionDataOption) bool {
+
+	changed := false
+	if opt, ok := optMap["cadence"]; ok {
+		standup.ScheduleType = opt.StringValue()
+		changed = true
+	}
+	if opt, ok := optMap["cron"]; ok {
+		standup.CronExpr = strings.TrimSpace(opt.StringValue())
+		if _, hasCadence := optMap["cadence"]; !hasCadence {
+			standup.ScheduleType = models.ScheduleCron
+		}
+		changed = true
+	}
+	if opt, ok := optMap["every_weeks"]; ok {
+		standup.IntervalWeeks = int(opt.IntValue())
+		changed = true
+	}
+	if opt, ok := optMap["anchor_date"]; ok {
+		standup.AnchorDate = strings.TrimSpace(opt.StringValue())
+		changed = true
+	}
+	if opt, ok := optMap["month_week"]; ok {
+		standup.MonthWeek = int(opt.IntValue())
+		changed = true
+	}
+
+	switch standup.ScheduleType {
+	case models.ScheduleInterval:
+		if standup.IntervalWeeks == 0 {
+			standup.IntervalWeeks = 2
+		}
+		if standup.AnchorDate == "" {
+			standup.AnchorDate = time.Now().UTC().Format("2006-01-02")
+		}
+	case models.ScheduleMonthly:
+		if standup.MonthWeek == 0 {
+			standup.MonthWeek = 1
+		}
+	}
+	return changed
+}
+
+func formatOverride(override models.StandupParticipant) string {
+	var parts []string
+	if override.Time != "" {
+		parts = append(parts, fmt.Sprintf("**%s**", override.Time))
+	}
+	if override.Days != "" {
+		parts = append(parts, strings.ReplaceAll(override.Days, ",", ", "))
+	}
+	if len(parts) == 0 {
+		return "Standup default"
+	}
+	return strings.Join(parts, " on ")
+}
+
+// formatPause says how long a paused standup is on hold, e.g. "until 2025-03-10 (Offsite)".
+func formatPause(standup models.Standup) string {
+	until := "until a manager resumes it"
+	if standup.ResumeOn != "" {
+		until = "until " + standup.ResumeOn
+	}
+	if standup.PauseReason != "" {
+		until += fmt.Sprintf(" (%s)", standup.PauseReason)
+	}
+	return until
+}
+
+func formatReportMode(standup models.Standup) string {
+	if standup.IsDigest() {
+		return fmt.Sprintf("Daily digest at %s (%s)", standup.DigestTime, standupZoneLabel(standup))
+	}
+	if standup.DailyThread {
+		return "Individual posts in a daily thread"
+	}
+	return "Individual posts"
+}
+
+// parseReminderOffsets turns "1h,3h" into minutes after the prompt. "off" clears them.
+func parseReminderOffsets(raw string) ([]int64, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == "off" || raw == "none" {
+		return nil, nil
+	}
+
+	var offsets []int64
+	for _, part := range strings.Split(raw, ",") {
+		delay, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || delay < time.Minute || delay >= 24*time.Hour {
+			return nil, fmt.Errorf("invalid reminder delay %q", part)
+		}
+		offsets = append(offsets, int64(delay/time.Minute))
+	}
+
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return offsets, nil
+}
+
+func formatReminderRules(standup models.Standup) string {
+	if len(standup.ReminderOffsets) == 0 {
+		return "Off"
+	}
+
+	var delays []string
+	for _, minutes := range standup.ReminderOffsets {
+		if minutes%60 == 0 {
+			delays = append(delays, fmt.Sprintf("%dh", minutes/60))
+		} else if minutes > 60 {
+			delays = append(delays, fmt.Sprintf("%dh%dm", minutes/60, minutes%60))
+		} else {
+			delays = append(delays, fmt.Sprintf("%dm", minutes))
+		}
+	}
+
+	rules := "After " + strings.Join(delays, ", ")
+	if standup.MaxReminders > 0 {
+		rules += fmt.Sprintf(" (max %d)", standup.MaxReminders)
+	}
+	return rules
+}
+
 func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *discordgo.InteractionCreate) {
     userID := utils.ExtractUserID(intr)
     optMap := utils.ParseCommandOptions(intr)
@@ -70,15 +256,30 @@ func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *d
     channelID := optMap["channel"].ChannelValue(session).ID
     membersRaw := optMap["members"].StringValue()
 
-    standupTime := "09:00"
+    standupTime := ""
     if opt, ok := optMap["time"]; ok {
         standupTime = opt.StringValue()
     }
 
-    defaultQuestions := []string{
-        "What did you accomplish yesterday?",
-        "What will you do today?",
-        "Are you stuck anywhere? (Blockers)",
+    standupTZ := ""
+    if opt, ok := optMap["timezone"]; ok {
+        var valid bool
+        if standupTZ, valid = parseStandupTimezone(opt.StringValue()); !valid {
+            utils.RespondWithError(session, intr.Interaction,
+                "⛔ Unknown timezone. Use an IANA name such as `Europe/London` or `America/New_York`.")
+            return
+        }
+    }
+
+    templateRef := models.TemplateBuiltin + ":" + services.DefaultTemplateKey
+    if opt, ok := optMap["template"]; ok {
+        templateRef = opt.StringValue()
+    }
+    template, err := h.StandupService.FindTemplate(intr.GuildID, userID, templateRef)
+    if err != nil {
+        utils.RespondWithError(session, intr.Interaction,
+            "⛔ "+err.Error()+". Run `/template list` to see the templates you can use.")
+        return
     }
 
     standupInput := models.Standup{
@@ -87,8 +288,16 @@ func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *d
         GuildID:         intr.GuildID,
         ManagerID:       userID,
         Time:            standupTime,
-        Questions:       defaultQuestions,
-        Days:            "Monday,Tuesday,Wednesday,Thursday,Friday",
+        Timezone:        standupTZ,
+    }
+    template.Apply(&standupInput)
+    if standupInput.Time == "" {
+        standupInput.Time = "09:00"
+    }
+    applyCadenceOptions(&standupInput, optMap)
+    if standupInput.ScheduleType == models.ScheduleMonthly && template.ScheduleType != models.ScheduleMonthly {
+        // "First Monday-Friday of the month" is rarely what anyone wants.
+        standupInput.Days = "Monday"
     }
 
     var manager models.UserProfile
@@ -96,7 +305,7 @@ func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *d
 
     createdStandup, err := h.StandupService.CreateStandup(standupInput)
     if err != nil {
-        utils.RespondWithMessage(session, intr, "❌ Failed to create standup.", true)
+        utils.RespondWithMessage(session, intr, "❌ Failed to create standup: "+err.Error(), true)
         return
     }
 
@@ -116,7 +325,7 @@ func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *d
             go func(uID string, tz string) {
                 dmChannel, err := session.UserChannelCreate(uID)
                 if err == nil {
-                    timeDisplay := utils.FormatLocalTime(createdStandup.Time, tz)
+                    timeDisplay := services.FormatLocalTime(createdStandup.Time, createdStandup.Timezone, tz)
                     welcomeMsg := fmt.Sprintf("👋 **You've been added to the '%s' Standup!**\n\n"+
                         "⏰ Scheduled for: %s\nRun `/start` here or in the server to begin.",
                         createdStandup.Name, timeDisplay)
@@ -126,13 +335,17 @@ func (h *StandupHandler) handleCreateStandup(session *discordgo.Session, intr *d
         }
     }
 
-    timeDisplay := utils.FormatLocalTime(createdStandup.Time, manager.Timezone)
+    h.StandupService.InvalidateStandup(createdStandup.ID)
+
+    timeDisplay := services.FormatLocalTime(createdStandup.Time, createdStandup.Timezone, manager.Timezone)
     successMsg := fmt.Sprintf("🎉 **Standup '%s' created successfully!**\n"+
-        "⏰ Scheduled for: **%s** on **Monday-Friday**\n"+
+        "⏰ Scheduled for: %s\n"+
+        "🗓️ Cadence: **%s**\n"+
         "👥 Added **%d** members.\n\n"+
-        "💡 *I have assigned the standard 3 Agile questions. Use `/edit-standup` "+
+        "💡 *Set up from the **%s** template with %d questions. Use `/edit-standup` "+
         "to customize your questions or active days!*",
-        createdStandup.Name, timeDisplay, addedCount)
+        createdStandup.Name, timeDisplay, services.FormatCadence(*createdStandup), addedCount,
+        template.Name, len(createdStandup.Questions))
 
     utils.RespondWithMessage(session, intr, successMsg, true)
 }
@@ -141,7 +354,7 @@ func (h *StandupHandler) handleEditStandup(session *discordgo.Session, intr *dis
 	optMap := utils.ParseCommandOptions(intr)
 	standupName := optMap["standup_name"].StringValue()
 
-	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName)
+	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName, models.AccessManage)
 	if !authorized {
 		return
 	}
@@ -154,21 +367,99 @@ func (h *StandupHandler) handleEditStandup(session *discordgo.Session, intr *dis
 	}
 
 	if opt, ok := optMap["new_time"]; ok {
-		newTime := opt.StringValue()
-		var hTime, mTime int
-		if _, err := fmt.Sscanf(newTime, "%d:%d", &hTime, &mTime); err != nil ||
-			hTime < 0 || hTime > 23 || mTime < 0 || mTime > 59 {
+		newTime, valid := parseClockTime(opt.StringValue())
+		if !valid {
 			utils.RespondWithError(session, intr.Interaction,
 				"⛔ Invalid time format. Please use HH:MM in 24h format (e.g., 09:30).")
 			return
 		}
-		standup.Time = fmt.Sprintf("%02d:%02d", hTime, mTime)
+		standup.Time = newTime
 		updatedFields = append(updatedFields, fmt.Sprintf("Trigger Time (%s)", standup.Time))
 	}
 
+	if opt, ok := optMap["timezone"]; ok {
+		standupTZ, valid := parseStandupTimezone(opt.StringValue())
+		if !valid {
+			utils.RespondWithError(session, intr.Interaction,
+				"⛔ Unknown timezone. Use an IANA name such as `Europe/London`, or 'local'.")
+			return
+		}
+		standup.Timezone = standupTZ
+		if standupTZ == "" {
+			updatedFields = append(updatedFields, "Timezone (Local to each user)")
+		} else {
+			updatedFields = append(updatedFields, fmt.Sprintf("Timezone (%s)", standupTZ))
+		}
+	}
+
+	if applyCadenceOptions(standup, optMap) {
+		updatedFields = append(updatedFields, fmt.Sprintf("Cadence (%s)", services.FormatCadence(*standup)))
+	}
+
+	if opt, ok := optMap["deadline"]; ok {
+		raw := strings.ToLower(strings.TrimSpace(opt.StringValue()))
+		if raw == "off" || raw == "none" {
+			standup.CutoffTime = ""
+		} else {
+			cutoff, valid := parseClockTime(raw)
+			if !valid {
+				utils.RespondWithError(session, intr.Interaction,
+					"⛔ Invalid deadline. Please use HH:MM in 24h format (e.g., 11:00) or 'off'.")
+				return
+			}
+			standup.CutoffTime = cutoff
+		}
+		updatedFields = append(updatedFields, fmt.Sprintf("Deadline (%s)", formatCutoff(*standup)))
+	}
+
+	if opt, ok := optMap["reminders"]; ok {
+		offsets, err := parseReminderOffsets(opt.StringValue())
+		if err != nil {
+			utils.RespondWithError(session, intr.Interaction,
+				"⛔ Invalid reminders. Use comma-separated delays under 24h (e.g. 30m,1h,3h) or 'off'.")
+			return
+		}
+		standup.ReminderOffsets = offsets
+		updatedFields = append(updatedFields, fmt.Sprintf("Reminders (%s)", formatReminderRules(*standup)))
+	}
+
+	if opt, ok := optMap["max_reminders"]; ok {
+		standup.MaxReminders = int(opt.IntValue())
+		updatedFields = append(updatedFields, fmt.Sprintf("Max Reminders (%s)", formatReminderRules(*standup)))
+	}
+
+	if opt, ok := optMap["digest_time"]; ok {
+		digestTime, valid := parseClockTime(opt.StringValue())
+		if !valid {
+			utils.RespondWithError(session, intr.Interaction,
+				"⛔ Invalid digest time. Please use HH:MM in 24h format (e.g., 12:00).")
+			return
+		}
+		standup.DigestTime = digestTime
+		updatedFields = append(updatedFields, fmt.Sprintf("Digest Time (%s)", standup.DigestTime))
+	}
+
+	if opt, ok := optMap["report_mode"]; ok {
+		standup.ReportMode = opt.StringValue()
+		if standup.IsDigest() && standup.DigestTime == "" {
+			utils.RespondWithError(session, intr.Interaction,
+				"⛔ Digest mode needs a `digest_time` so I know when to publish it.")
+			return
+		}
+		updatedFields = append(updatedFields, fmt.Sprintf("Report Mode (%s)", formatReportMode(*standup)))
+	}
+
+	if opt, ok := optMap["daily_thread"]; ok {
+		standup.DailyThread = opt.BoolValue()
+		updatedFields = append(updatedFields, fmt.Sprintf("Daily Thread (%s)", formatReportMode(*standup)))
+	}
+
 	responseMsg := fmt.Sprintf("⚙️ **Managing %s**\n", standup.Name)
 	if len(updatedFields) > 0 {
-		h.DB.Save(standup)
+		if err := h.StandupService.UpdateStandup(*standup); err != nil {
+			utils.RespondWithError(session, intr.Interaction, "⛔ Could not save changes: "+err.Error())
+			return
+		}
 		responseMsg += fmt.Sprintf("✅ *Saved changes to:*\n- %s\n\n", strings.Join(updatedFields, "\n- "))
 	} else {
 		responseMsg += "ℹ️ No basic settings were changed.\n\n"
@@ -215,7 +506,7 @@ func (h *StandupHandler) handleEditDaysSubmit(session *discordgo.Session,
 
 	selectedDays := intr.MessageComponentData().Values
 	standup.Days = strings.Join(selectedDays, ",")
-	h.DB.Save(&standup)
+	h.StandupService.UpdateStandup(standup)
 
 	prettyDays := strings.ReplaceAll(standup.Days, ",", ", ")
 
@@ -260,7 +551,7 @@ func (h *StandupHandler) handleDeleteStandup(session *discordgo.Session, intr *d
 		targetName = opt.StringValue()
 	}
 
-	standup, authorized := h.fetchAuthorizedStandup(session, intr, targetName)
+	standup, authorized := h.fetchAuthorizedStandup(session, intr, targetName, models.AccessOwn)
 	if !authorized {
 		return
 	}
@@ -278,7 +569,7 @@ func (h *StandupHandler) handleAddMember(session *discordgo.Session, intr *disco
 	targetUser := optMap["user"].UserValue(session)
 	standupName := optMap["standup_name"].StringValue()
 
-	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName)
+	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName, models.AccessManage)
 	if !authorized {
 		return
 	}
@@ -293,8 +584,16 @@ func (h *StandupHandler) handleAddMember(session *discordgo.Session, intr *disco
 		return
 	}
 
-	if err := h.StandupService.AddMemberToStandup(targetUser.ID, standup.ID); err != nil {
-		utils.RespondWithError(session, intr.Interaction, "Failed to add member.")
+	var override models.StandupParticipant
+	if opt, ok := optMap["time"]; ok {
+		override.Time = opt.StringValue()
+	}
+	if opt, ok := optMap["days"]; ok {
+		override.Days = opt.StringValue()
+	}
+
+	if err := h.StandupService.AddMemberToStandup(targetUser.ID, standup.ID, override); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "Failed to add member: "+err.Error())
 		return
 	}
 
@@ -307,7 +606,7 @@ func (h *StandupHandler) handleRemoveMember(session *discordgo.Session, intr *di
 	targetUser := optMap["user"].UserValue(session)
 	standupName := optMap["standup_name"].StringValue()
 
-	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName)
+	standup, authorized := h.fetchAuthorizedStandup(session, intr, standupName, models.AccessManage)
 	if !authorized {
 		return
 	}
@@ -341,6 +640,9 @@ func (h *StandupHandler) handleStandupInfo(session *discordgo.Session, intr *dis
 			fmt.Sprintf("❌ Standup named **%s** not found.", standupName))
 		return
 	}
+	if !h.authorizeStandup(session, intr, standup, models.AccessOwnReports) {
+		return
+	}
 
 	activeDays := standup.Days
 	if activeDays == "" {
@@ -351,7 +653,7 @@ func (h *StandupHandler) handleStandupInfo(session *discordgo.Session, intr *dis
 
 	var qList strings.Builder
 	for i, q := range standup.Questions {
-		qList.WriteString(fmt.Sprintf("**%d.** %s\n", i+1, q))
+		qList.WriteString(fmt.Sprintf("**%d.** %s%s\n", i+1, q, formatQuestionKind(standup.QuestionSpec(i))))
 	}
 	if len(standup.Questions) == 0 {
 		qList.WriteString("*No questions configured.*")
@@ -369,16 +671,69 @@ func (h *StandupHandler) handleStandupInfo(session *discordgo.Session, intr *dis
 		memberStr = "*No members added yet.*"
 	}
 
+	rolesStr := "*Nobody else helps run this standup.*"
+	if members, err := h.StandupService.GetMembers(standup.ID); err == nil && len(members) > 0 {
+		var lines []string
+		for _, member := range members {
+			lines = append(lines, fmt.Sprintf("<@%s>: %s", member.UserID, models.RoleName(member.Role)))
+		}
+		rolesStr = strings.Join(lines, "\n")
+		if len(rolesStr) > 1000 {
+			rolesStr = fmt.Sprintf("*%d people (List too long to display)*", len(members))
+		}
+	}
+
+	var overrideLines []string
+	for userID, override := range h.StandupService.StandupOverrides(standup.ID) {
+		overrideLines = append(overrideLines, fmt.Sprintf("<@%s>: %s", userID, formatOverride(override)))
+	}
+	sort.Strings(overrideLines)
+	overrideStr := strings.Join(overrideLines, "\n")
+	if len(overrideLines) == 0 {
+		overrideStr = "*Everyone follows the standup schedule.*"
+	} else if len(overrideStr) > 1000 {
+		overrideStr = fmt.Sprintf("*%d members have their own schedule*", len(overrideLines))
+	}
+
+	statusStr := "▶️ Active"
+	if standup.IsPaused() {
+		statusStr = "⏸️ Paused " + formatPause(standup)
+	}
+
+	syncStr := "*Members are added by hand.*"
+	if len(standup.SyncRoleIDs) > 0 {
+		var mentions []string
+		for _, roleID := range standup.SyncRoleIDs {
+			mentions = append(mentions, fmt.Sprintf("<@&%s>", roleID))
+		}
+		syncStr = strings.Join(mentions, ", ")
+	}
+
+	triggerTime := fmt.Sprintf("**%s** (Local to each user)", standup.Time)
+	if standup.Timezone != "" {
+		var viewer models.UserProfile
+		h.DB.Where("user_id = ?", utils.ExtractUserID(intr)).First(&viewer)
+		triggerTime = services.FormatLocalTime(standup.Time, standup.Timezone, viewer.Timezone)
+	}
+
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("📊 Standup Info: %s", standup.Name),
 		Color:       0x5865F2,
 		Description: "Here is the current configuration for this team.",
 		Fields: []*discordgo.MessageEmbedField{
 			{Name: "👑 Manager", Value: fmt.Sprintf("<@%s>", standup.ManagerID), Inline: true},
+			{Name: "🚦 Status", Value: statusStr, Inline: true},
+			{Name: "🛡️ Co-managers & Observers", Value: rolesStr, Inline: false},
 			{Name: "📢 Report Channel", Value: fmt.Sprintf("<#%s>", standup.ReportChannelID), Inline: true},
-			{Name: "⏰ Trigger Time", Value: fmt.Sprintf("**%s** (Local to each user)", standup.Time), Inline: true},
+			{Name: "⏰ Trigger Time", Value: triggerTime, Inline: true},
 			{Name: "📅 Active Days", Value: activeDays, Inline: false},
+			{Name: "🗓️ Cadence", Value: services.FormatCadence(standup), Inline: false},
+			{Name: "🔁 Reminders", Value: formatReminderRules(standup), Inline: true},
+			{Name: "⏳ Deadline", Value: formatCutoff(standup), Inline: true},
+			{Name: "📰 Report Mode", Value: formatReportMode(standup), Inline: true},
 			{Name: fmt.Sprintf("👥 Members (%d)", len(standup.Participants)), Value: memberStr, Inline: false},
+			{Name: "🔗 Synced Roles", Value: syncStr, Inline: false},
+			{Name: "🕒 Personal Schedules", Value: overrideStr, Inline: false},
 			{Name: "📝 Questions", Value: qList.String(), Inline: false},
 		},
 	}
@@ -404,7 +759,7 @@ func (h *StandupHandler) showQuestionDashboard(session *discordgo.Session,
 	var options []discordgo.SelectMenuOption
 
 	for i, q := range standup.Questions {
-		qList.WriteString(fmt.Sprintf("**%d.** %s\n", i+1, q))
+		qList.WriteString(fmt.Sprintf("**%d.** %s%s\n", i+1, q, formatQuestionKind(standup.QuestionSpec(i))))
 
 		label := q
 		if len(label) > 90 {
@@ -478,20 +833,8 @@ func (h *StandupHandler) handleEditSingleQuestionPrompt(session *discordgo.Sessi
 		Data: &discordgo.InteractionResponseData{
 			CustomID: fmt.Sprintf("edit_single_q_%d_%d", standup.ID, qIndex),
 			Title:    fmt.Sprintf("Edit Question %d", qIndex+1),
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.TextInput{
-							CustomID:  "q_text",
-							Label:     "Question Text (Clear to Delete)",
-							Style:     discordgo.TextInputParagraph,
-							Value:     standup.Questions[qIndex],
-							Required:  false,
-							MaxLength: 300,
-						},
-					},
-				},
-			},
+			Components: questionModalComponents("Question Text (Clear to Delete)", standup.Questions[qIndex],
+				false, standup.QuestionSpec(qIndex)),
 		},
 	})
 }
@@ -503,43 +846,228 @@ func (h *StandupHandler) handleAddQuestionPrompt(session *discordgo.Session,
 		Data: &discordgo.InteractionResponseData{
 			CustomID: fmt.Sprintf("add_single_q_%d", standupID),
 			Title:    "Add New Question",
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.TextInput{
-							CustomID:  "q_text",
-							Label:     "Type your new question",
-							Style:     discordgo.TextInputParagraph,
-							Required:  true,
-							MaxLength: 300,
-						},
-					},
-				},
-			},
+			Components: questionModalComponents("Type your new question", "", true,
+				models.QuestionSpec{Type: models.QuestionText}),
 		},
 	})
 }
 
+// questionModalComponents builds the question editor: the text plus how it is answered.
+func questionModalComponents(textLabel, text string, textRequired bool,
+	spec models.QuestionSpec) []discordgo.MessageComponent {
+
+	answerType := spec.Type
+	if spec.Optional {
+		answerType += ", optional"
+	}
+	if spec.Blocker {
+		answerType += ", blocker"
+	}
+	if spec.CarryFrom != nil {
+		answerType += fmt.Sprintf(", from Q%d", *spec.CarryFrom+1)
+	}
+	limits := ""
+	if spec.MinLength > 0 || spec.MaxLength > 0 {
+		limits = fmt.Sprintf("%d-%d", spec.MinLength, spec.MaxLength)
+	}
+	condition := ""
+	if spec.ShowIf != nil {
+		condition = fmt.Sprintf("Q%d", spec.ShowIf.Question+1)
+		if len(spec.ShowIf.Answers) > 0 {
+			condition += " = " + strings.Join(spec.ShowIf.Answers, " | ")
+		}
+	}
+
+	inputs := []discordgo.TextInput{
+		{CustomID: "q_text", Label: textLabel, Style: discordgo.TextInputParagraph,
+			Value: text, Required: textRequired, MaxLength: 300},
+		{CustomID: "q_type", Label: "Answer Type", Style: discordgo.TextInputShort,
+			Value: answerType, Placeholder: "text, short, select, yes_no or scale, plus ', optional', ', blocker' or ', from Q2'",
+			Required: false},
+		{CustomID: "q_choices", Label: "Choices (select only, one per line)", Style: discordgo.TextInputParagraph,
+			Value: strings.Join(spec.Choices, "\n"), Required: false},
+		{CustomID: "q_length", Label: "Length limits for text (min-max, e.g. 10-500)", Style: discordgo.TextInputShort,
+			Value: limits, Required: false, MaxLength: 9},
+		{CustomID: "q_condition", Label: "Only ask if (e.g. Q2 = yes, blank = always)", Style: discordgo.TextInputShort,
+			Value: condition, Placeholder: "Q2 = yes | maybe, or just Q2 for any answer", Required: false},
+	}
+
+	rows := make([]discordgo.MessageComponent, len(inputs))
+	for i, input := range inputs {
+		rows[i] = discordgo.ActionsRow{Components: []discordgo.MessageComponent{input}}
+	}
+	return rows
+}
+
+// parseQuestionSpec reads the answer settings back out of questionModalComponents.
+func parseQuestionSpec(values map[string]string) (models.QuestionSpec, error) {
+	var spec models.QuestionSpec
+
+	// Flags follow the type after commas, e.g. "text, optional, blocker".
+	parts := strings.Split(strings.ToLower(values["q_type"]), ",")
+	answerType := strings.TrimSpace(parts[0])
+	for _, flag := range parts[1:] {
+		switch strings.TrimSpace(flag) {
+		case "optional":
+			spec.Optional = true
+		case "blocker", "blockers":
+			spec.Blocker = true
+		case "":
+		default:
+			// "from Q2" starts the answer from the user's last answer to question 2.
+			var from int
+			if _, err := fmt.Sscanf(strings.TrimSpace(flag), "from q%d", &from); err == nil && from > 0 {
+				from--
+				spec.CarryFrom = &from
+				continue
+			}
+			return spec, fmt.Errorf("unknown option %q, use 'optional', 'blocker' or 'from Q2'",
+				strings.TrimSpace(flag))
+		}
+	}
+	if answerType == "optional" {
+		answerType, spec.Optional = "", true
+	}
+
+	switch answerType {
+	case "", "text", "paragraph", "long":
+		spec.Type = models.QuestionText
+	case "short":
+		spec.Type = models.QuestionShort
+	case "select", "choice", "multiple choice":
+		spec.Type = models.QuestionSelect
+	case "yes_no", "yes/no", "yesno", "boolean":
+		spec.Type = models.QuestionYesNo
+	case "scale", "1-5", "rating":
+		spec.Type = models.QuestionScale
+	default:
+		return spec, fmt.Errorf("unknown answer type %q", values["q_type"])
+	}
+
+	if spec.Type == models.QuestionSelect {
+		spec.Choices = strings.Split(values["q_choices"], "\n")
+	}
+
+	if limits := strings.TrimSpace(values["q_length"]); limits != "" &&
+		(spec.Type == models.QuestionText || spec.Type == models.QuestionShort) {
+		if _, err := fmt.Sscanf(limits, "%d-%d", &spec.MinLength, &spec.MaxLength); err != nil {
+			return spec, errors.New("length limits must look like 10-500")
+		}
+	}
+
+	if raw := strings.TrimSpace(values["q_condition"]); raw != "" {
+		target, answers, _ := strings.Cut(raw, "=")
+		var question int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(target)), "Q"),
+			"%d", &question); err != nil || question < 1 {
+			return spec, errors.New("conditions must look like Q2 = yes")
+		}
+		spec.ShowIf = &models.QuestionCondition{Question: question - 1}
+		if strings.TrimSpace(answers) != "" {
+			spec.ShowIf.Answers = strings.Split(answers, "|")
+		}
+	}
+	return spec, nil
+}
+
+func formatQuestionKind(spec models.QuestionSpec) string {
+	var tags []string
+	switch spec.Type {
+	case models.QuestionShort:
+		tags = append(tags, "short answer")
+	case models.QuestionSelect:
+		tags = append(tags, "choice: "+strings.Join(spec.Choices, " / "))
+	case models.QuestionYesNo:
+		tags = append(tags, "yes/no")
+	case models.QuestionScale:
+		tags = append(tags, fmt.Sprintf("%d-%d scale", models.ScaleMin, models.ScaleMax))
+	}
+	if spec.Optional {
+		tags = append(tags, "optional")
+	}
+	if spec.Blocker {
+		tags = append(tags, "🚧 blocker question")
+	}
+	if spec.CarryFrom != nil {
+		tags = append(tags, fmt.Sprintf("starts from last Q%d answer", *spec.CarryFrom+1))
+	}
+	if spec.ShowIf != nil {
+		if len(spec.ShowIf.Answers) == 0 {
+			tags = append(tags, fmt.Sprintf("only if Q%d is answered", spec.ShowIf.Question+1))
+		} else {
+			tags = append(tags, fmt.Sprintf("only if Q%d = %s", spec.ShowIf.Question+1,
+				strings.Join(spec.ShowIf.Answers, " or ")))
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" *(%s)*", strings.Join(tags, ", "))
+}
+
 func (h *StandupHandler) handleQuestionSubmit(session *discordgo.Session,
 	intr *discordgo.InteractionCreate, standupID uint, qIndex int, isNew bool) {
 	var standup models.Standup
-	h.DB.First(&standup, standupID)
+	if err := h.DB.First(&standup, standupID).Error; err != nil {
+		utils.RespondWithError(session, intr.Interaction, "Standup not found.")
+		return
+	}
+
+	values := make(map[string]string)
+	for _, row := range intr.ModalSubmitData().Components {
+		if input, ok := row.(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput); ok {
+			values[input.CustomID] = input.Value
+		}
+	}
+	newText := strings.TrimSpace(values["q_text"])
 
-	newText := strings.TrimSpace(intr.ModalSubmitData().
-		Components[0].(*discordgo.ActionsRow).
-		Components[0].(*discordgo.TextInput).Value)
+	spec, err := parseQuestionSpec(values)
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error())
+		return
+	}
 
-	if isNew {
-		standup.Questions = append(standup.Questions, newText)
+	if !isNew && newText == "" && qIndex < len(standup.Questions) {
+		// Deleting shifts the questions after it, so follow-up conditions are repointed.
+		var questions []string
+		var from []int
+		for i, q := range standup.Questions {
+			if i != qIndex {
+				questions = append(questions, q)
+				from = append(from, i)
+			}
+		}
+		services.RemapQuestions(&standup, questions, from)
 	} else {
-		if newText == "" {
-			standup.Questions = append(standup.Questions[:qIndex], standup.Questions[qIndex+1:]...)
-		} else {
+		// Give every question its spec first so the two lists stay aligned.
+		specs := make([]models.QuestionSpec, len(standup.Questions))
+		for i := range specs {
+			specs[i] = standup.QuestionSpec(i)
+		}
+
+		// Only one question tracks blockers, so marking this one moves the flag here.
+		if spec.Blocker {
+			for i := range specs {
+				specs[i].Blocker = false
+			}
+		}
+
+		if isNew {
+			standup.Questions = append(standup.Questions, newText)
+			specs = append(specs, spec)
+		} else if qIndex < len(standup.Questions) {
+			// Rewording keeps the question's ID; old reports keep the wording they were asked.
+			spec.ID = specs[qIndex].ID
 			standup.Questions[qIndex] = newText
+			specs[qIndex] = spec
 		}
+		standup.QuestionSpecs = specs
 	}
 
-	h.DB.Save(&standup)
+	if err := h.StandupService.UpdateStandup(standup); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ Failed to save question: "+err.Error())
+		return
+	}
 	h.showQuestionDashboard(session, intr, standup.ID, true)
 }
 
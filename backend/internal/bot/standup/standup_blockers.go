@@ -0,0 +1,122 @@
+package standup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxListedBlockers keeps the /blockers embed within Discord's description limit.
+const maxListedBlockers = 20
+
+// handleBlockers lists open blockers: every one in the standups the caller manages or
+// observes (all of the server's for admins), or just their own when they have none.
+func (h *StandupHandler) handleBlockers(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	userID := utils.ExtractUserID(intr)
+
+	var standupName string
+	for _, opt := range intr.ApplicationCommandData().Options {
+		if opt.Name == "standup_name" {
+			standupName = opt.StringValue()
+		}
+	}
+
+	var standups []models.Standup
+	if standupName != "" {
+		standup, ok := h.fetchAuthorizedStandup(session, intr, standupName, models.AccessView)
+		if !ok {
+			return
+		}
+		standups = []models.Standup{*standup}
+	} else if utils.IsServerAdmin(intr) {
+		h.DB.Where("guild_id = ?", intr.GuildID).Find(&standups)
+	} else {
+		h.DB.Where("guild_id = ?", intr.GuildID).
+			Where(h.StandupService.AccessibleStandups(userID, models.AccessView)).Find(&standups)
+	}
+
+	names := make(map[uint]string)
+	var standupIDs []uint
+	for _, st := range standups {
+		names[st.ID] = st.Name
+		standupIDs = append(standupIDs, st.ID)
+	}
+
+	blockers, err := h.StandupService.GetBlockers(standupIDs, models.BlockerOpen)
+	title := "🚧 Open Blockers"
+	if err == nil && len(standupIDs) == 0 {
+		title = "🚧 Your Open Blockers"
+		err = h.DB.Where("user_id = ? AND status = ?", userID, models.BlockerOpen).
+			Order("created_at asc").Find(&blockers).Error
+		for _, blocker := range blockers {
+			if _, ok := names[blocker.StandupID]; !ok {
+				var standup models.Standup
+				h.DB.Select("id", "name").First(&standup, blocker.StandupID)
+				names[blocker.StandupID] = standup.Name
+			}
+		}
+	}
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "❌ Failed to load blockers.")
+		return
+	}
+
+	if len(blockers) == 0 {
+		utils.RespondWithMessage(session, intr, "✅ No open blockers. Nothing is standing in the way!", true)
+		return
+	}
+
+	now := time.Now()
+	var lines []string
+	for i, blocker := range blockers {
+		if i == maxListedBlockers {
+			lines = append(lines, fmt.Sprintf("*…and %d more.*", len(blockers)-maxListedBlockers))
+			break
+		}
+		text := blocker.Text
+		if len(text) > 150 {
+			text = text[:147] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("**#%d** <@%s> · %s · open **%s**\n> %s", blocker.ID,
+			blocker.UserID, names[blocker.StandupID], services.FormatBlockerAge(blocker.Age(now)), text))
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       title,
+				Description: strings.Join(lines, "\n\n"),
+				Color:       0xda373c,
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: "Use the ✅ Resolved button on a report to close its blocker.",
+				},
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleResolveBlocker closes the blocker behind a "Resolved" button, on the report
+// itself or in the manager's DM, and swaps the button for its resolved state.
+func (h *StandupHandler) handleResolveBlocker(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, blockerID uint) {
+
+	blocker, err := h.StandupService.ResolveBlocker(blockerID, utils.ExtractUserID(intr))
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Components: services.BlockerComponents(*blocker),
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+	"github.com/Gurkunwar/asyncflow/internal/store"
+	"github.com/bwmarrin/discordgo"
+)
+
+// OnDirectMessage takes the DMs of users in chat mode as answers to their current
+// standup question. It fills the same state as the form flow and submits through
+// finalizeStandup once the last question is answered.
+func (h *StandupHandler) OnDirectMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author == nil || m.Author.Bot || m.GuildID != "" {
+		return
+	}
+	userID := m.Author.ID
+
+	standupID := store.GetChatSession(h.Redis, userID)
+	if standupID == 0 {
+		var profile models.UserProfile
+		if err := h.DB.Where("user_id = ?", userID).First(&profile).Error; err == nil && profile.ChatMode {
+			s.ChannelMessageSend(m.ChannelID, "💤 No standup is waiting for answers. Run `/start` to begin one.")
+		}
+		return
+	}
+
+	// Same per-user lock as the form flow, so a DM and a button press can't both submit.
+	// Only messages that answer a chat session take it.
+	lockKey := "submit:" + userID
+	if !store.AcquireLease(h.Redis, lockKey, m.ID, submitLockTTL) {
+		s.ChannelMessageSend(m.ChannelID, "⏳ Still saving your previous answer. Please send that again in a moment.")
+		return
+	}
+	defer store.ReleaseLease(h.Redis, lockKey, m.ID)
+
+	redisKey := fmt.Sprintf("%s_%d", userID, standupID)
+	state, err := store.GetState(h.Redis, redisKey)
+	var standup models.Standup
+	if err == nil {
+		err = h.DB.First(&standup, standupID).Error
+	}
+	if err != nil {
+		store.ClearChatSession(h.Redis, userID)
+		s.ChannelMessageSend(m.ChannelID, "⚠️ Your standup session expired. Run `/start` to begin again.")
+		return
+	}
+
+	lastAsked := -1
+	if len(state.Asked) > 0 {
+		lastAsked = state.Asked[len(state.Asked)-1]
+	}
+	qIndex := nextQuestion(standup, *state, lastAsked+1)
+	reply := strings.TrimSpace(m.Content)
+
+	switch strings.ToLower(reply) {
+	case "cancel":
+		store.ClearChatSession(h.Redis, userID)
+		h.Redis.Del(context.Background(), "state:"+redisKey)
+		s.ChannelMessageSend(m.ChannelID, "🛑 Standup cancelled. Run `/start` whenever you're ready.")
+		return
+
+	case "back":
+		if len(state.Asked) == 0 {
+			h.sendChatQuestion(s, m.ChannelID, userID, standup, qIndex, "↩️ This is the first question.\n\n")
+			return
+		}
+		last := len(state.Asked) - 1
+		previous := state.Asked[last]
+		state.Asked = state.Asked[:last]
+		state.Answers = state.Answers[:last]
+		store.SaveState(h.Redis, redisKey, *state)
+		h.sendChatQuestion(s, m.ChannelID, userID, standup, previous, "↩️ Going back.\n\n")
+		return
+	}
+
+	if qIndex >= len(standup.Questions) {
+		h.finishChatStandup(s, m.ChannelID, state)
+		return
+	}
+
+	spec := standup.QuestionSpec(qIndex)
+	answer := ""
+	if strings.EqualFold(reply, "skip") {
+		if !spec.Optional {
+			h.sendChatQuestion(s, m.ChannelID, userID, standup, qIndex, "⚠️ This question can't be skipped.\n\n")
+			return
+		}
+	} else if strings.EqualFold(reply, "same") && spec.CarryFrom != nil {
+		answer = h.StandupService.CarriedAnswer(userID, standup, *spec.CarryFrom)
+		if answer == "" {
+			h.sendChatQuestion(s, m.ChannelID, userID, standup, qIndex, "⚠️ There is no earlier answer to keep.\n\n")
+			return
+		}
+	} else if answer, err = services.ParseAnswer(spec, reply); err != nil {
+		h.sendChatQuestion(s, m.ChannelID, userID, standup, qIndex, "⚠️ "+err.Error()+"\n\n")
+		return
+	}
+
+	state.Asked = append(state.Asked, qIndex)
+	state.Answers = append(state.Answers, answer)
+	store.SaveState(h.Redis, redisKey, *state)
+
+	if next := nextQuestion(standup, *state, qIndex+1); next < len(standup.Questions) {
+		h.sendChatQuestion(s, m.ChannelID, userID, standup, next, "")
+		return
+	}
+	h.finishChatStandup(s, m.ChannelID, state)
+}
+
+// sendChatQuestion asks a question as a plain DM, telling the user how typed questions
+// expect to be answered.
+func (h *StandupHandler) sendChatQuestion(s *discordgo.Session, channelID, userID string,
+	standup models.Standup, qIndex int, header string) {
+
+	if qIndex >= len(standup.Questions) {
+		return
+	}
+	spec := standup.QuestionSpec(qIndex)
+
+	hint := ""
+	switch spec.Type {
+	case models.QuestionSelect:
+		choices := make([]string, len(spec.Choices))
+		for i, choice := range spec.Choices {
+			choices[i] = fmt.Sprintf("`%d` %s", i+1, choice)
+		}
+		hint = "\nReply with one of: " + strings.Join(choices, " · ")
+	case models.QuestionYesNo:
+		hint = "\nReply `yes` or `no`."
+	case models.QuestionScale:
+		hint = fmt.Sprintf("\nReply with a number from %d (lowest) to %d (highest).", models.ScaleMin, models.ScaleMax)
+	}
+	if spec.CarryFrom != nil {
+		if carried := h.StandupService.CarriedAnswer(userID, standup, *spec.CarryFrom); carried != "" {
+			hint += "\nLast time you planned:\n> " + strings.ReplaceAll(carried, "\n", "\n> ") +
+				"\nReply `same` to keep it as it is."
+		}
+	}
+	if spec.Optional {
+		hint += "\n*Optional: reply `skip` to leave it blank.*"
+	}
+
+	if _, err := s.ChannelMessageSend(channelID, fmt.Sprintf("%s**%s (%d/%d)**\n> %s%s", header,
+		standup.Name, qIndex+1, len(standup.Questions), standup.Questions[qIndex], hint)); err != nil {
+		log.Printf("Error sending chat question to %s: %v", channelID, err)
+	}
+}
+
+func (h *StandupHandler) finishChatStandup(s *discordgo.Session, channelID string,
+	state *models.StandupState) {
+
+	store.ClearChatSession(h.Redis, state.UserID)
+	h.Redis.Del(context.Background(), "state:"+fmt.Sprintf("%s_%d", state.UserID, state.StandupID))
+
+	history := h.finalizeStandup(s, state)
+	if history == nil {
+		s.ChannelMessageSend(channelID, "❌ Something went wrong submitting your standup. Please try `/start` again.")
+		return
+	}
+
+	var standup models.Standup
+	h.DB.First(&standup, state.StandupID)
+
+	completeMsg := "✅ **Standup complete!** Your team has been notified."
+	if history.EditedAt != nil {
+		completeMsg = "✅ **Standup complete!** You had already reported today, so your earlier report was replaced."
+	} else if standup.IsDigest() {
+		completeMsg = "✅ **Standup complete!** Your update will be included in today's team digest."
+	}
+
+	s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:    completeMsg,
+		Components: reportComponents(history.ID),
+	})
+}
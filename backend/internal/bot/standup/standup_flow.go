@@ -9,6 +9,7 @@ import (
 
 	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
 	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
 	"github.com/Gurkunwar/asyncflow/internal/store"
 	"github.com/bwmarrin/discordgo"
 )
@@ -44,6 +45,28 @@ func (h *StandupHandler) syncDiscordProfile(session *discordgo.Session,
 	return userProfile, userName, avatarURL
 }
 
+// maxModalInputs is the most text inputs Discord allows in one modal.
+const maxModalInputs = 5
+
+// submitLockTTL bounds how long one interaction can hold a user's lock if it never
+// releases it.
+const submitLockTTL = 30 * time.Second
+
+// lockUser serialises a user's answers, skips and submissions so double clicks or two
+// sessions at once can't both save. When the lock is taken it tells the user to retry
+// and reports false; otherwise the returned func releases it.
+func (h *StandupHandler) lockUser(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, userID string) (func(), bool) {
+
+	key := "submit:" + userID
+	if !store.AcquireLease(h.Redis, key, intr.ID, submitLockTTL) {
+		utils.RespondWithError(session, intr.Interaction,
+			"⏳ Still saving your previous answer. Please try again in a moment.")
+		return nil, false
+	}
+	return func() { store.ReleaseLease(h.Redis, key, intr.ID) }, true
+}
+
 func (h *StandupHandler) InitiateStandup(s *discordgo.Session, userID string,
 	guildID, channelID string, standupID uint) {
 
@@ -101,6 +124,12 @@ func (h *StandupHandler) InitiateStandup(s *discordgo.Session, userID string,
 		return
 	}
 
+	if targetStandup.IsPaused() {
+		s.ChannelMessageSend(targetChannelID, fmt.Sprintf("⏸️ **%s** is paused %s, so there is nothing to answer yet.",
+			targetStandup.Name, formatPause(targetStandup)))
+		return
+	}
+
 	if profile.Timezone == "" {
 		newTimezone := "UTC"
 
@@ -115,15 +144,16 @@ func (h *StandupHandler) InitiateStandup(s *discordgo.Session, userID string,
 
 		profile.Timezone = newTimezone
 		h.DB.Save(&profile)
+		h.StandupService.InvalidateUser(profile.UserID)
 	}
 
 	showTZWarning := profile.Timezone == "UTC"
 
-	h.startQuestionFlow(s, targetChannelID, userID, targetStandup, showTZWarning)
+	h.startQuestionFlow(s, targetChannelID, userID, targetStandup, showTZWarning, profile.ChatMode)
 }
 
 func (h *StandupHandler) startQuestionFlow(session *discordgo.Session,
-	channelID, userID string, standup models.Standup, showTZWarning bool) {
+	channelID, userID string, standup models.Standup, showTZWarning, chatMode bool) {
 
 	state := models.StandupState{
 		UserID:    userID,
@@ -136,39 +166,103 @@ func (h *StandupHandler) startQuestionFlow(session *discordgo.Session,
 	store.SaveState(h.Redis, redisKey, state)
 
 	msgContent := "Ready to submit your daily standup?"
+	if chatMode {
+		msgContent = fmt.Sprintf("Time for your **%s** standup! Just reply to each question here. "+
+			"Reply `skip` to skip an optional question, `back` to redo the previous one, or `cancel` to stop.",
+			standup.Name)
+	}
 	if showTZWarning {
 		msgContent += "\n\nℹ️ *Note: Daily reminders are scheduled in UTC. Use `/timezone` to change.*"
 	}
 
 	session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
-		Content: msgContent,
-		Components: []discordgo.MessageComponent{
-			discordgo.ActionsRow{
-				Components: []discordgo.MessageComponent{
-					discordgo.Button{
-						Label:    "Fill Standup",
-						Style:    discordgo.PrimaryButton,
-						CustomID: fmt.Sprintf("open_standup_modal_%d", standup.ID),
-					},
-					discordgo.Button{
-						Label:    "⏭️ Skip Today",
-						Style:    discordgo.SecondaryButton,
-						CustomID: fmt.Sprintf("skip_standup_%d", standup.ID),
-					},
+		Content:    msgContent,
+		Components: standupPromptComponents(standup.ID),
+	})
+
+	if chatMode && len(standup.Questions) > 0 {
+		store.SetChatSession(h.Redis, userID, standup.ID)
+		h.sendChatQuestion(session, channelID, userID, standup, nextQuestion(standup, state, 0), "")
+	}
+}
+
+func standupPromptComponents(standupID uint) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Fill Standup",
+					Style:    discordgo.PrimaryButton,
+					CustomID: fmt.Sprintf("open_standup_modal_%d", standupID),
+				},
+				discordgo.Button{
+					Label:    "⏭️ Skip Today",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("skip_standup_%d", standupID),
 				},
 			},
 		},
+	}
+}
+
+// SendReminder nudges a participant who hasn't submitted yet. It carries the same
+// buttons as the original prompt so they can answer straight from the reminder.
+func (h *StandupHandler) SendReminder(s *discordgo.Session, userID string, standupID uint, reminderNumber int) {
+	var standup models.Standup
+	if err := h.DB.First(&standup, standupID).Error; err != nil {
+		return
+	}
+
+	dm, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return
+	}
+
+	s.ChannelMessageSendComplex(dm.ID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("⏰ **Reminder #%d:** You haven't submitted your **%s** standup yet today.",
+			reminderNumber, standup.Name),
+		Components: standupPromptComponents(standup.ID),
 	})
 }
 
-func (h *StandupHandler) openSingleAnswerModal(session *discordgo.Session,
-	intr *discordgo.InteractionCreate, standupIDStr string, qIndex int) {
-	var standupID uint
-	fmt.Sscanf(standupIDStr, "%d", &standupID)
+// beginAnswers starts a fresh set of answers for the standup, or a revision of the
+// report editingHistoryID when it is set, and asks the first question.
+func (h *StandupHandler) beginAnswers(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, standupID uint, editingHistoryID uint) {
+
+	var standup models.Standup
+	if err := h.DB.First(&standup, standupID).Error; err != nil {
+		log.Println("Error fetching standup for question:", err)
+		return
+	}
+
+	state := models.StandupState{
+		UserID:           intr.User.ID,
+		GuildID:          standup.GuildID,
+		StandupID:        standup.ID,
+		Answers:          []string{},
+		EditingHistoryID: editingHistoryID,
+	}
+	redisKey := fmt.Sprintf("%s_%d", intr.User.ID, standup.ID)
+	store.SaveState(h.Redis, redisKey, state)
+
+	// Opening the form takes over from a chat-mode session for the same standup.
+	if store.GetChatSession(h.Redis, intr.User.ID) == standup.ID {
+		store.ClearChatSession(h.Redis, intr.User.ID)
+	}
+
+	h.askQuestion(session, intr, standup.ID, 0)
+}
+
+// askQuestion presents the qIndex-th question: select menus and buttons on the message
+// itself for the kinds a modal can't render, otherwise one modal holding as many of the
+// following text questions as fit.
+func (h *StandupHandler) askQuestion(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, standupID uint, qIndex int) {
 
 	var standup models.Standup
 	if err := h.DB.First(&standup, standupID).Error; err != nil {
-		log.Println("Error fetching standup for modal:", err)
+		log.Println("Error fetching standup for question:", err)
 		return
 	}
 
@@ -178,47 +272,151 @@ func (h *StandupHandler) openSingleAnswerModal(session *discordgo.Session,
 		return
 	}
 
-	if qIndex == 0 {
-		state := models.StandupState{
-			UserID:    intr.User.ID,
-			GuildID:   standup.GuildID,
-			StandupID: standup.ID,
-			Answers:   []string{},
+	if standup.QuestionSpec(qIndex).IsTyped() {
+		session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: choiceQuestionMessage(standup, qIndex, "", h.previousAnswer(intr.User.ID, standup, qIndex)),
+		})
+		return
+	}
+
+	state, err := store.GetState(h.Redis, fmt.Sprintf("%s_%d", intr.User.ID, standup.ID))
+	if err != nil {
+		state = &models.StandupState{}
+	}
+	h.openAnswerModal(session, intr, standup, modalBatch(standup, *state, qIndex))
+}
+
+// modalBatch picks the questions from qIndex on that can share one modal: consecutive text
+// questions, up to Discord's five inputs, passing over follow-ups already ruled out. A
+// follow-up that depends on an answer inside the batch ends it, since that answer isn't
+// known until the modal is submitted.
+func modalBatch(standup models.Standup, state models.StandupState, qIndex int) []int {
+	batch := []int{qIndex}
+	for q := qIndex + 1; q < len(standup.Questions) && len(batch) < maxModalInputs; q++ {
+		spec := standup.QuestionSpec(q)
+		if spec.IsTyped() {
+			break
+		}
+		if condition := spec.ShowIf; condition != nil {
+			if condition.Question >= qIndex {
+				break
+			}
+			if !condition.Matches(state.AnswerTo(condition.Question)) {
+				continue
+			}
 		}
-		redisKey := fmt.Sprintf("%s_%d", intr.User.ID, standup.ID)
-		store.SaveState(h.Redis, redisKey, state)
+		batch = append(batch, q)
 	}
+	return batch
+}
 
-	questionText := standup.Questions[qIndex]
+// editingReport returns the submitted report the user is revising, or nil when they are
+// answering from scratch.
+func (h *StandupHandler) editingReport(userID string, standupID uint) *models.StandupHistory {
+	state, err := store.GetState(h.Redis, fmt.Sprintf("%s_%d", userID, standupID))
+	if err != nil || state.EditingHistoryID == 0 {
+		return nil
+	}
 
-	label := questionText
-	if len(label) > 45 {
-		label = label[:42] + "..."
+	var history models.StandupHistory
+	if err := h.DB.First(&history, state.EditingHistoryID).Error; err != nil {
+		return nil
 	}
+	return &history
+}
 
-	placeholder := questionText
-	if len(placeholder) > 100 {
-		placeholder = placeholder[:97] + "..."
+// previousAnswer is what the user answered to question q in the report they are editing,
+// used to prefill it. It is empty for new submissions.
+func (h *StandupHandler) previousAnswer(userID string, standup models.Standup, q int) string {
+	history := h.editingReport(userID, standup.ID)
+	if history == nil {
+		return ""
 	}
+	answer, _ := history.AnswerToQuestion(standup, q)
+	return answer
+}
 
-	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseModal,
-		Data: &discordgo.InteractionResponseData{
-			CustomID: fmt.Sprintf("standup_answer_modal_%d_%d", standup.ID, qIndex),
-			Title:    fmt.Sprintf("%s (%d/%d)", standup.Name, qIndex+1, len(standup.Questions)),
+func (h *StandupHandler) openAnswerModal(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, standup models.Standup, questions []int) {
+
+	editing := h.editingReport(intr.User.ID, standup.ID)
+
+	var rows []discordgo.MessageComponent
+	for _, q := range questions {
+		questionText := standup.Questions[q]
+		spec := standup.QuestionSpec(q)
+
+		label := questionText
+		if len(label) > 45 {
+			label = label[:42] + "..."
+		}
+
+		current := ""
+		if editing != nil {
+			current, _ = editing.AnswerToQuestion(standup, q)
+		}
+
+		// The label already shows the question, so the placeholder only repeats it when
+		// the label had to be cut short.
+		placeholder := "Your answer"
+		if label != questionText {
+			placeholder = questionText
+		}
+		if spec.CarryFrom != nil && editing == nil {
+			if carried := h.StandupService.CarriedAnswer(intr.User.ID, standup, *spec.CarryFrom); carried != "" {
+				placeholder = "Last time you planned: " + strings.Join(strings.Fields(carried), " ")
+				current = carried
+				if spec.MaxLength > 0 && len([]rune(current)) > spec.MaxLength {
+					current = string([]rune(current)[:spec.MaxLength])
+				}
+			}
+		}
+		if spec.Optional {
+			placeholder = "(Optional) " + placeholder
+		}
+		if runes := []rune(placeholder); len(runes) > 100 {
+			placeholder = string(runes[:97]) + "..."
+		}
+
+		style := discordgo.TextInputParagraph
+		if spec.Type == models.QuestionShort {
+			style = discordgo.TextInputShort
+		}
+
+		rows = append(rows, discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.TextInput{
-							CustomID:    "answer_text",
-							Label:       label,
-							Style:       discordgo.TextInputParagraph,
-							Required:    true,
-							Placeholder: placeholder,
-						},
-					},
+				discordgo.TextInput{
+					CustomID:    fmt.Sprintf("answer_%d", q),
+					Label:       label,
+					Style:       style,
+					Required:    !spec.Optional,
+					Placeholder: placeholder,
+					Value:       current,
+					MinLength:   spec.MinLength,
+					MaxLength:   spec.MaxLength,
 				},
 			},
+		})
+	}
+
+	first, last := questions[0], questions[len(questions)-1]
+	progress := fmt.Sprintf("%d/%d", first+1, len(standup.Questions))
+	if last != first {
+		progress = fmt.Sprintf("%d-%d/%d", first+1, last+1, len(standup.Questions))
+	}
+
+	title := fmt.Sprintf("%s (%s)", standup.Name, progress)
+	if len(title) > 45 {
+		title = title[:42] + "..."
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   fmt.Sprintf("standup_answer_modal_%d_%d", standup.ID, first),
+			Title:      title,
+			Components: rows,
 		},
 	})
 
@@ -227,11 +425,181 @@ func (h *StandupHandler) openSingleAnswerModal(session *discordgo.Session,
 	}
 }
 
-func (h *StandupHandler) handleSingleAnswerSubmit(session *discordgo.Session,
+// choiceQuestionMessage renders a select, yes/no or scale question as message components.
+// current is the answer being revised, if any.
+func choiceQuestionMessage(standup models.Standup, qIndex int,
+	header, current string) *discordgo.InteractionResponseData {
+
+	spec := standup.QuestionSpec(qIndex)
+	choiceID := fmt.Sprintf("standup_choice_%d_%d", standup.ID, qIndex)
+
+	var rows []discordgo.MessageComponent
+	switch spec.Type {
+	case models.QuestionSelect:
+		var options []discordgo.SelectMenuOption
+		for i, choice := range spec.Choices {
+			options = append(options, discordgo.SelectMenuOption{
+				Label:   choice,
+				Value:   fmt.Sprintf("%d", i),
+				Default: choice == current,
+			})
+		}
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    choiceID,
+					Placeholder: "Choose an answer...",
+					Options:     options,
+				},
+			},
+		})
+
+	case models.QuestionYesNo:
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: "Yes", Style: discordgo.SuccessButton, CustomID: choiceID + "_yes"},
+				discordgo.Button{Label: "No", Style: discordgo.DangerButton, CustomID: choiceID + "_no"},
+			},
+		})
+
+	case models.QuestionScale:
+		var buttons []discordgo.MessageComponent
+		for score := models.ScaleMin; score <= models.ScaleMax; score++ {
+			buttons = append(buttons, discordgo.Button{
+				Label:    fmt.Sprintf("%d", score),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("%s_%d", choiceID, score),
+			})
+		}
+		rows = append(rows, discordgo.ActionsRow{Components: buttons})
+	}
+
+	if spec.Optional {
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: "Skip Question", Style: discordgo.SecondaryButton,
+					CustomID: choiceID + "_skip"},
+			},
+		})
+	}
+
+	hint := ""
+	if spec.Type == models.QuestionScale {
+		hint = fmt.Sprintf(" *(%d = lowest, %d = highest)*", models.ScaleMin, models.ScaleMax)
+	}
+	if current != "" {
+		hint += fmt.Sprintf("\nCurrent answer: **%s**", current)
+	}
+
+	return &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf("%s**%s (%d/%d)**\n> %s%s", header, standup.Name, qIndex+1,
+			len(standup.Questions), standup.Questions[qIndex], hint),
+		Components: rows,
+	}
+}
+
+func (h *StandupHandler) handleAnswerModalSubmit(session *discordgo.Session,
 	intr *discordgo.InteractionCreate, standupID uint, qIndex int) {
-	answer := intr.ModalSubmitData().
-		Components[0].(*discordgo.ActionsRow).
-		Components[0].(*discordgo.TextInput).Value
+
+	var questions []int
+	var answers []string
+	for _, row := range intr.ModalSubmitData().Components {
+		input, ok := row.(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput)
+		if !ok {
+			continue
+		}
+
+		// Modals opened before grouping held a single "answer_text" input.
+		q := qIndex
+		if input.CustomID != "answer_text" {
+			if _, err := fmt.Sscanf(input.CustomID, "answer_%d", &q); err != nil {
+				continue
+			}
+		}
+		questions = append(questions, q)
+		answers = append(answers, strings.TrimSpace(input.Value))
+	}
+	if len(questions) == 0 {
+		return
+	}
+
+	// Discord enforces the input limits, but the questions may have changed since the
+	// modal was opened.
+	var standup models.Standup
+	if err := h.DB.First(&standup, standupID).Error; err != nil {
+		utils.RespondWithMessage(session, intr, "❌ Standup not found. It may have been deleted.", true)
+		return
+	}
+	for i, q := range questions {
+		if q >= len(standup.Questions) {
+			utils.RespondWithMessage(session, intr, "⚠️ This standup's questions were just updated. Please try again.", true)
+			return
+		}
+		answer, err := services.ParseAnswer(standup.QuestionSpec(q), answers[i])
+		if err != nil {
+			utils.RespondWithMessage(session, intr,
+				fmt.Sprintf("⚠️ **%s**\n%s", standup.Questions[q], err.Error()), true)
+			return
+		}
+		answers[i] = answer
+	}
+
+	h.recordAnswers(session, intr, standupID, questions, answers)
+}
+
+// handleChoiceAnswer turns a select menu pick or button press into the stored answer.
+// raw is the button's value suffix, or empty for select menus.
+func (h *StandupHandler) handleChoiceAnswer(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, standupID uint, qIndex int, raw string) {
+
+	var standup models.Standup
+	if err := h.DB.First(&standup, standupID).Error; err != nil || qIndex >= len(standup.Questions) {
+		utils.UpdateMessage(session, intr, "❌ Standup not found. It may have been deleted.", nil)
+		return
+	}
+	spec := standup.QuestionSpec(qIndex)
+
+	answer, err := "", error(nil)
+	switch {
+	case raw == "skip" && spec.Optional:
+	case spec.Type == models.QuestionSelect:
+		var choice int
+		values := intr.MessageComponentData().Values
+		if len(values) == 0 {
+			return
+		}
+		if _, err := fmt.Sscanf(values[0], "%d", &choice); err != nil || choice < 0 || choice >= len(spec.Choices) {
+			return
+		}
+		answer = spec.Choices[choice]
+	case spec.Type == models.QuestionYesNo || spec.Type == models.QuestionScale:
+		answer, err = services.ParseAnswer(spec, raw)
+	default:
+		err = fmt.Errorf("%q no longer answers question %d", raw, qIndex+1)
+	}
+	if err != nil {
+		// The question was changed since these buttons were sent.
+		session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: choiceQuestionMessage(standup, qIndex, "⚠️ This question was just updated.\n\n",
+				h.previousAnswer(intr.User.ID, standup, qIndex)),
+		})
+		return
+	}
+
+	h.recordAnswers(session, intr, standupID, []int{qIndex}, []string{answer})
+}
+
+// recordAnswers saves the answers to the given questions, in the order they were asked,
+// and moves on to the next question, or submits the standup after the last one.
+func (h *StandupHandler) recordAnswers(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, standupID uint, questions []int, answers []string) {
+
+	release, ok := h.lockUser(session, intr, intr.User.ID)
+	if !ok {
+		return
+	}
+	defer release()
 
 	redisKey := fmt.Sprintf("%s_%d", intr.User.ID, standupID)
 	state, err := store.GetState(h.Redis, redisKey)
@@ -246,20 +614,50 @@ func (h *StandupHandler) handleSingleAnswerSubmit(session *discordgo.Session,
 		return
 	}
 
-	state.Answers = append(state.Answers, strings.TrimSpace(answer))
+	if len(state.Asked) == 0 && len(state.Answers) > 0 {
+		// Sessions started before follow-ups existed answered every question in order.
+		for i := range state.Answers {
+			state.Asked = append(state.Asked, i)
+		}
+	}
+
+	// Answering a question again (an old button, a double click) replaces that answer and
+	// everything asked after it.
+	first, last := questions[0], questions[len(questions)-1]
+	for i, asked := range state.Asked {
+		if asked >= first {
+			state.Asked = state.Asked[:i]
+			state.Answers = state.Answers[:i]
+			break
+		}
+	}
+	state.Asked = append(state.Asked, questions...)
+	state.Answers = append(state.Answers, answers...)
 	store.SaveState(h.Redis, redisKey, *state)
 
 	var standup models.Standup
 	h.DB.First(&standup, standupID)
 
-	nextQIndex := qIndex + 1
+	nextQIndex := nextQuestion(standup, *state, last+1)
 
 	if nextQIndex < len(standup.Questions) {
+		header := fmt.Sprintf("✅ **Question %d answered!**\n\n", first+1)
+		if len(questions) > 1 {
+			header = fmt.Sprintf("✅ **Questions %d-%d answered!**\n\n", first+1, last+1)
+		}
+		if standup.QuestionSpec(nextQIndex).IsTyped() {
+			session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseUpdateMessage,
+				Data: choiceQuestionMessage(standup, nextQIndex, header,
+					h.previousAnswer(intr.User.ID, standup, nextQIndex)),
+			})
+			return
+		}
+
 		session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content: fmt.Sprintf("✅ **Question %d answered!**\n\nReady for question %d?",
-					qIndex+1, nextQIndex+1),
+				Content: fmt.Sprintf("%sReady for question %d?", header, nextQIndex+1),
 				Components: []discordgo.MessageComponent{
 					discordgo.ActionsRow{
 						Components: []discordgo.MessageComponent{
@@ -276,18 +674,116 @@ func (h *StandupHandler) handleSingleAnswerSubmit(session *discordgo.Session,
 		return
 	}
 
+	if state.EditingHistoryID != 0 {
+		h.finishReportEdit(session, intr, state)
+		h.Redis.Del(context.Background(), "state:"+redisKey)
+		return
+	}
+
+	completeMsg := "✅ **Standup complete!** Your team has been notified."
+	if standup.IsDigest() {
+		completeMsg = "✅ **Standup complete!** Your update will be included in today's team digest."
+	}
+
 	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseUpdateMessage,
 		Data: &discordgo.InteractionResponseData{
-			Content:    "✅ **Standup complete!** Your team has been notified.",
+			Content:    completeMsg,
 			Components: []discordgo.MessageComponent{},
 		},
 	})
 
-	h.finalizeStandup(session, state)
+	if history := h.finalizeStandup(session, state); history != nil {
+		components := reportComponents(history.ID)
+		edit := &discordgo.WebhookEdit{Components: &components}
+		if history.EditedAt != nil {
+			replacedMsg := "✅ **Standup complete!** You had already reported today, so your earlier report was replaced."
+			edit.Content = &replacedMsg
+		}
+		session.InteractionResponseEdit(intr.Interaction, edit)
+	}
 	h.Redis.Del(context.Background(), "state:"+redisKey)
 }
 
+// reportComponents lets the author revise or take back a report they submitted.
+func reportComponents(historyID uint) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "✏️ Edit my report",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("edit_report_%d", historyID),
+				},
+				discordgo.Button{
+					Label:    "🗑️ Retract",
+					Style:    discordgo.DangerButton,
+					CustomID: fmt.Sprintf("retract_report_%d", historyID),
+				},
+			},
+		},
+	}
+}
+
+func (h *StandupHandler) handleEditReport(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, historyID uint) {
+
+	history, err := h.StandupService.GetOwnReport(historyID, utils.ExtractUserID(intr))
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "❌ "+err.Error()+".")
+		return
+	}
+	h.beginAnswers(session, intr, history.StandupID, history.ID)
+}
+
+func (h *StandupHandler) finishReportEdit(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, state *models.StandupState) {
+
+	history, err := h.StandupService.GetOwnReport(state.EditingHistoryID, state.UserID)
+	if err != nil {
+		utils.UpdateMessage(session, intr, "❌ That report no longer exists, so there is nothing to update.", nil)
+		return
+	}
+
+	if err := h.StandupService.UpdateReport(history, state.Answers, state.Asked, nil); err != nil {
+		if services.IsValidationError(err) {
+			// The message names the question whose answer no longer fits.
+			utils.UpdateMessage(session, intr, "❌ "+err.Error(), nil)
+			return
+		}
+		log.Printf("Error updating report %d: %v", history.ID, err)
+		utils.UpdateMessage(session, intr, "❌ Failed to update your report: "+err.Error(), nil)
+		return
+	}
+
+	utils.UpdateMessage(session, intr, "✅ **Report updated!** The posted update now shows your changes.",
+		reportComponents(history.ID))
+}
+
+func (h *StandupHandler) handleRetractReport(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, historyID uint) {
+
+	if err := h.StandupService.RetractReport(historyID, utils.ExtractUserID(intr)); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "❌ "+err.Error()+".")
+		return
+	}
+	utils.UpdateMessage(session, intr,
+		"🗑️ Your report was retracted. Run `/start` if you'd like to submit a new one.", nil)
+}
+
+// nextQuestion returns the first question from `from` on that should be asked, passing
+// over follow-ups whose condition on an earlier answer isn't met, or len(Questions) when
+// none are left.
+func nextQuestion(standup models.Standup, state models.StandupState, from int) int {
+	for q := from; q < len(standup.Questions); q++ {
+		condition := standup.QuestionSpec(q).ShowIf
+		if condition == nil || condition.Matches(state.AnswerTo(condition.Question)) {
+			return q
+		}
+	}
+	return len(standup.Questions)
+}
+
 func (h *StandupHandler) handleSkipStandup(session *discordgo.Session,
 	intr *discordgo.InteractionCreate, standupID uint) {
 	userID := utils.ExtractUserID(intr)
@@ -302,13 +798,45 @@ func (h *StandupHandler) handleSkipStandup(session *discordgo.Session,
 
 	localToday := utils.GetUserLocalTime(userProfile.Timezone).Format("2006-01-02")
 
+	release, ok := h.lockUser(session, intr, userID)
+	if !ok {
+		return
+	}
+	defer release()
+
+	// Skipping is only for days without an entry: a report has to be retracted first.
+	if existing := h.StandupService.DayEntry(userID, standupID, localToday); existing != nil {
+		msg := "ℹ️ You have already skipped today's standup."
+		if !existing.IsMarker() {
+			msg = "ℹ️ You've already submitted today's report. Use **🗑️ Retract** on it first " +
+				"if you'd rather skip today."
+		}
+		utils.UpdateMessage(session, intr, msg, nil)
+		return
+	}
+
+	if store.GetChatSession(h.Redis, userID) == standupID {
+		store.ClearChatSession(h.Redis, userID)
+	}
+
 	history := models.StandupHistory{
 		UserID:    userID,
 		StandupID: standupID,
 		Date:      localToday,
-		Answers:   []string{"Skipped / OOO"},
+		Answers:   []string{models.SkippedAnswer},
+	}
+	if err := h.DB.Create(&history).Error; err != nil {
+		log.Println("❌ Error saving skipped standup:", err)
+		utils.UpdateMessage(session, intr, "❌ Couldn't record your skip. Please try again.", nil)
+		return
+	}
+
+	if standup.IsDigest() {
+		h.StandupService.UpdateDigest(standup, history)
+		utils.UpdateMessage(session, intr,
+			"✅ You have successfully skipped today's standup. It will show up in the team digest.", nil)
+		return
 	}
-	h.DB.Create(&history)
 
 	embed := &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
@@ -321,22 +849,30 @@ func (h *StandupHandler) handleSkipStandup(session *discordgo.Session,
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}
 
-	session.ChannelMessageSendComplex(standup.ReportChannelID, &discordgo.MessageSend{
-		Content: fmt.Sprintf("<@%s>", userID),
-		Embeds:  []*discordgo.MessageEmbed{embed},
-	})
+	msg, err := session.ChannelMessageSendComplex(h.StandupService.ReportChannel(standup, userID, localToday),
+		&discordgo.MessageSend{
+			Content: fmt.Sprintf("<@%s>", userID),
+			Embeds:  []*discordgo.MessageEmbed{embed},
+		})
+	if err == nil {
+		// Remembered so a report submitted later in the day can replace it.
+		h.DB.Model(&history).Updates(map[string]interface{}{"channel_id": msg.ChannelID, "message_id": msg.ID})
+	}
+	h.StandupService.RefreshThreadSummary(standup, userID, localToday)
 
 	utils.UpdateMessage(session, intr,
 		"✅ You have successfully skipped today's standup. Your team has been notified!", nil)
 }
 
-func (h *StandupHandler) finalizeStandup(s *discordgo.Session, state *models.StandupState) {
+func (h *StandupHandler) finalizeStandup(s *discordgo.Session,
+	state *models.StandupState) *models.StandupHistory {
+
 	var standup models.Standup
 	result := h.DB.First(&standup, state.StandupID)
 
 	if result.Error != nil || standup.ReportChannelID == "" {
 		log.Printf("Could not find standup config for ID %d", state.StandupID)
-		return
+		return nil
 	}
 
 	userProfile, userName, avatarURL := h.syncDiscordProfile(s, state.UserID)
@@ -348,41 +884,14 @@ func (h *StandupHandler) finalizeStandup(s *discordgo.Session, state *models.Sta
 		StandupID: state.StandupID,
 		Date:      localToday,
 		Answers:   state.Answers,
+		Asked:     state.Asked,
 	}
 
-	if err := h.DB.Create(&history).Error; err != nil {
+	if _, err := h.StandupService.SubmitReport(standup, &history, userName, avatarURL); err != nil {
 		log.Println("❌ Error saving standup history to database:", err)
+		return nil
 	}
-
-	var fields []*discordgo.MessageEmbedField
-	for i, answer := range state.Answers {
-		questionText := "Update"
-		if i < len(standup.Questions) {
-			questionText = standup.Questions[i]
-		}
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   questionText,
-			Value:  "👉 " + answer,
-			Inline: false,
-		})
-	}
-
-	embed := &discordgo.MessageEmbed{
-		Author: &discordgo.MessageEmbedAuthor{
-			Name:    fmt.Sprintf("%s's Standup", userName),
-			IconURL: avatarURL,
-		},
-		Title:       fmt.Sprintf("🚀 %s Update", standup.Name),
-		Description: fmt.Sprintf("Progress report from **%s**", userName),
-		Color:       0x5865F2,
-		Fields:      fields,
-		Timestamp:   time.Now().Format(time.RFC3339),
-	}
-
-	s.ChannelMessageSendComplex(standup.ReportChannelID, &discordgo.MessageSend{
-		Content: fmt.Sprintf("<@%s>", state.UserID),
-		Embeds:  []*discordgo.MessageEmbed{embed},
-	})
+	return &history
 }
 
 func (h *StandupHandler) sendStandupSelectionMenu(s *discordgo.Session,
@@ -454,6 +963,7 @@ func (h *StandupHandler) handleStandupSelection(session *discordgo.Session,
 	}
 
 	h.DB.Model(&user).Association("Standups").Append(&standup)
+	h.StandupService.InvalidateParticipant(userID, standup.ID)
 
 	utils.UpdateMessage(session, intr,
 		fmt.Sprintf("✅ You joined **%s**!",
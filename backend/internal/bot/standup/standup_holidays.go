@@ -0,0 +1,256 @@
+package standup
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+	"github.com/bwmarrin/discordgo"
+)
+
+// calendarDownloadTimeout bounds fetching an .ics attachment from Discord's CDN, which
+// happens inside an interaction handler.
+const calendarDownloadTimeout = 10 * time.Second
+
+func (h *StandupHandler) handleHolidays(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	data := intr.ApplicationCommandData()
+	subCommand := data.Options[0]
+
+	optMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subCommand.Options {
+		optMap[opt.Name] = opt
+	}
+
+	need := models.AccessManage
+	if subCommand.Name == "list" {
+		need = models.AccessView
+	}
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), need)
+	if !ok {
+		return
+	}
+
+	switch subCommand.Name {
+	case "import":
+		h.handleHolidayImport(session, intr, standup, optMap)
+	case "add":
+		h.handleHolidayAdd(session, intr, standup, optMap)
+	case "list":
+		h.handleHolidayList(session, intr, standup)
+	case "detach":
+		calendarName := optMap["calendar"].StringValue()
+		var calendar models.HolidayCalendar
+		if err := h.DB.Where("guild_id = ? AND name = ?", intr.GuildID, calendarName).
+			First(&calendar).Error; err != nil {
+			utils.RespondWithError(session, intr.Interaction,
+				fmt.Sprintf("Holiday calendar **%s** not found.", calendarName))
+			return
+		}
+		if err := h.StandupService.DetachHolidayCalendar(standup.ID, calendar.ID); err != nil {
+			utils.RespondWithError(session, intr.Interaction, "Failed to detach calendar: "+err.Error())
+			return
+		}
+		utils.RespondWithMessage(session, intr, fmt.Sprintf(
+			"✅ **%s** no longer skips the holidays in **%s**.", standup.Name, calendar.Name), true)
+	}
+}
+
+func (h *StandupHandler) handleHolidayImport(session *discordgo.Session, intr *discordgo.InteractionCreate,
+	standup *models.Standup, optMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+
+	attachmentID, _ := optMap["file"].Value.(string)
+	attachment, exists := intr.ApplicationCommandData().Resolved.Attachments[attachmentID]
+	if !exists {
+		utils.RespondWithError(session, intr.Interaction, "I couldn't read that attachment.")
+		return
+	}
+
+	calendarName := strings.TrimSuffix(attachment.Filename, filepath.Ext(attachment.Filename))
+	if opt, ok := optMap["calendar"]; ok {
+		calendarName = opt.StringValue()
+	}
+
+	// Downloading and parsing can outlast Discord's 3 second response window.
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+
+	reply := func(content string) {
+		session.InteractionResponseEdit(intr.Interaction, &discordgo.WebhookEdit{Content: &content})
+	}
+
+	tooLarge := "❌ That file is too large. Calendars are limited to 1 MB."
+	if attachment.Size > services.MaxCalendarBytes {
+		reply(tooLarge)
+		return
+	}
+
+	client := &http.Client{Timeout: calendarDownloadTimeout}
+	resp, err := client.Get(attachment.URL)
+	if err != nil {
+		reply("❌ Failed to download the attachment.")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		reply("❌ Failed to download the attachment.")
+		return
+	}
+
+	// Reading one byte past the limit tells an oversized file from one that just fits.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, services.MaxCalendarBytes+1))
+	if err != nil {
+		reply("❌ Failed to download the attachment.")
+		return
+	}
+	if len(body) > services.MaxCalendarBytes {
+		reply(tooLarge)
+		return
+	}
+
+	calendar, added, err := h.StandupService.ImportHolidayCalendar(standup.GuildID, calendarName, body)
+	if err != nil {
+		reply("❌ Could not import that calendar: " + err.Error())
+		return
+	}
+
+	if err := h.StandupService.AttachHolidayCalendar(standup.ID, calendar.ID); err != nil {
+		reply("❌ Imported the calendar but failed to attach it: " + err.Error())
+		return
+	}
+
+	reply(fmt.Sprintf("📅 Imported **%d** new holidays into **%s** and attached it to **%s**.",
+		added, calendar.Name, standup.Name))
+}
+
+func (h *StandupHandler) handleHolidayAdd(session *discordgo.Session, intr *discordgo.InteractionCreate,
+	standup *models.Standup, optMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+
+	start, err := time.Parse("2006-01-02", optMap["date"].StringValue())
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ Invalid date. Please use YYYY-MM-DD.")
+		return
+	}
+
+	end := start
+	if opt, ok := optMap["end_date"]; ok {
+		if end, err = time.Parse("2006-01-02", opt.StringValue()); err != nil || end.Before(start) {
+			utils.RespondWithError(session, intr.Interaction,
+				"⛔ Invalid end date. Please use YYYY-MM-DD, on or after the start date.")
+			return
+		}
+	}
+	if end.Sub(start) > 366*24*time.Hour {
+		utils.RespondWithError(session, intr.Interaction, "⛔ Holiday ranges are limited to one year.")
+		return
+	}
+
+	label := "Holiday"
+	if opt, ok := optMap["label"]; ok {
+		label = opt.StringValue()
+	}
+
+	calendarName := fmt.Sprintf("%s Holidays", standup.Name)
+	if opt, ok := optMap["calendar"]; ok {
+		calendarName = opt.StringValue()
+	}
+
+	var holidays []models.Holiday
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		holidays = append(holidays, models.Holiday{Date: day.Format("2006-01-02"), Name: label})
+	}
+
+	calendar, _, err := h.StandupService.CreateHolidayCalendar(standup.GuildID, calendarName, holidays)
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "Failed to save holiday: "+err.Error())
+		return
+	}
+	if err := h.StandupService.AttachHolidayCalendar(standup.ID, calendar.ID); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "Failed to attach calendar: "+err.Error())
+		return
+	}
+
+	dates := holidays[0].Date
+	if len(holidays) > 1 {
+		dates = fmt.Sprintf("%s → %s", holidays[0].Date, holidays[len(holidays)-1].Date)
+	}
+	utils.RespondWithMessage(session, intr, fmt.Sprintf(
+		"🏖️ **%s** (%s) added to **%s**. **%s** will skip it.", label, dates, calendar.Name, standup.Name), true)
+}
+
+func (h *StandupHandler) handleHolidayList(session *discordgo.Session, intr *discordgo.InteractionCreate,
+	standup *models.Standup) {
+
+	today := time.Now().UTC().Format("2006-01-02")
+	if err := h.DB.Preload("HolidayCalendars.Holidays", "date >= ?", today).First(standup, standup.ID).
+		Error; err != nil || len(standup.HolidayCalendars) == 0 {
+		utils.RespondWithMessage(session, intr, fmt.Sprintf(
+			"📭 **%s** has no holiday calendars. Use `/holidays add` or `/holidays import`.", standup.Name), true)
+		return
+	}
+
+	var fields []*discordgo.MessageEmbedField
+	for _, calendar := range standup.HolidayCalendars {
+		var lines []string
+		for i, holiday := range calendar.Holidays {
+			if i == 10 {
+				lines = append(lines, fmt.Sprintf("*...and %d more*", len(calendar.Holidays)-i))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("`%s` %s", holiday.Date, holiday.Name))
+		}
+		if len(lines) == 0 {
+			lines = append(lines, "*No upcoming holidays*")
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "📅 " + calendar.Name,
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:  fmt.Sprintf("🏖️ Holidays for %s", standup.Name),
+				Color:  0x5865F2,
+				Fields: fields,
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func (h *StandupHandler) respondCalendarChoices(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, typedValue string) {
+
+	var calendars []models.HolidayCalendar
+	h.DB.Where("guild_id = ?", intr.GuildID).Order("name asc").Find(&calendars)
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, calendar := range calendars {
+		if strings.Contains(strings.ToLower(calendar.Name), typedValue) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  calendar.Name,
+				Value: calendar.Name,
+			})
+		}
+	}
+	if len(choices) > 25 {
+		choices = choices[:25]
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package standup
+
+import (
+	"fmt"
+
+	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (h *StandupHandler) handlePauseStandup(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	optMap := utils.ParseCommandOptions(intr)
+
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), models.AccessManage)
+	if !ok {
+		return
+	}
+
+	var reason, resumeOn string
+	if opt, exists := optMap["reason"]; exists {
+		reason = opt.StringValue()
+	}
+	if opt, exists := optMap["resume_on"]; exists {
+		resumeOn = opt.StringValue()
+	}
+
+	if err := h.StandupService.PauseStandup(*standup, utils.ExtractUserID(intr), reason, resumeOn); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+
+	h.DB.First(standup, standup.ID)
+	utils.RespondWithMessage(session, intr, fmt.Sprintf("⏸️ **%s** is paused %s. Nobody will be prompted "+
+		"or reminded in the meantime.", standup.Name, formatPause(*standup)), true)
+}
+
+func (h *StandupHandler) handleResumeStandup(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	optMap := utils.ParseCommandOptions(intr)
+
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), models.AccessManage)
+	if !ok {
+		return
+	}
+
+	if err := h.StandupService.ResumeStandup(*standup, utils.ExtractUserID(intr)); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+	utils.RespondWithMessage(session, intr, fmt.Sprintf("▶️ **%s** is back on. I've let its members know.",
+		standup.Name), true)
+}
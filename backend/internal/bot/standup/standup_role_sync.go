@@ -0,0 +1,98 @@
+package standup
+
+import (
+	"fmt"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleRoleSync binds a standup to Discord roles, unbinds them, or resyncs its members
+// with the roles it already has.
+func (h *StandupHandler) handleRoleSync(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	subCommand := intr.ApplicationCommandData().Options[0]
+
+	optMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subCommand.Options {
+		optMap[opt.Name] = opt
+	}
+
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), models.AccessManage)
+	if !ok {
+		return
+	}
+
+	// Listing every server member can outlast Discord's 3 second response window.
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+
+	reply := func(content string) {
+		session.InteractionResponseEdit(intr.Interaction, &discordgo.WebhookEdit{Content: &content})
+	}
+
+	var role *discordgo.Role
+	if opt, exists := optMap["role"]; exists {
+		role = opt.RoleValue(session, intr.GuildID)
+	}
+
+	var added, removed int
+	var err error
+	var msg string
+	switch subCommand.Name {
+	case "add":
+		if role.Managed || role.ID == intr.GuildID {
+			reply("⛔ Pick a role people are given, not @everyone or a bot's own role.")
+			return
+		}
+		added, removed, err = h.StandupService.SetSyncRoles(*standup, append(standup.SyncRoleIDs, role.ID))
+		msg = fmt.Sprintf("🔗 **%s** now follows <@&%s>.", standup.Name, role.ID)
+	case "remove":
+		var roles []string
+		for _, id := range standup.SyncRoleIDs {
+			if id != role.ID {
+				roles = append(roles, id)
+			}
+		}
+		if len(roles) == len(standup.SyncRoleIDs) {
+			reply(fmt.Sprintf("ℹ️ **%s** isn't synced with <@&%s>.", standup.Name, role.ID))
+			return
+		}
+		added, removed, err = h.StandupService.SetSyncRoles(*standup, roles)
+		msg = fmt.Sprintf("🔗 **%s** no longer follows <@&%s>.", standup.Name, role.ID)
+	case "now":
+		added, removed, err = h.StandupService.ResyncStandupRoles(*standup)
+		msg = fmt.Sprintf("🔄 Resynced **%s** with its roles.", standup.Name)
+	}
+
+	if err != nil {
+		reply("❌ Role sync failed: " + err.Error() + ".")
+		return
+	}
+	reply(fmt.Sprintf("%s %d added, %d removed.", msg, added, removed))
+}
+
+// OnGuildMemberAdd syncs a member who joins the server into the standups of the roles
+// they arrive with.
+func (h *StandupHandler) OnGuildMemberAdd(session *discordgo.Session, event *discordgo.GuildMemberAdd) {
+	h.syncGuildMember(event.Member, true)
+}
+
+// OnGuildMemberUpdate follows role changes into and out of role-synced standups.
+func (h *StandupHandler) OnGuildMemberUpdate(session *discordgo.Session, event *discordgo.GuildMemberUpdate) {
+	h.syncGuildMember(event.Member, true)
+}
+
+// OnGuildMemberRemove takes a member who leaves the server out of the standups their
+// roles brought them into.
+func (h *StandupHandler) OnGuildMemberRemove(session *discordgo.Session, event *discordgo.GuildMemberRemove) {
+	h.syncGuildMember(event.Member, false)
+}
+
+func (h *StandupHandler) syncGuildMember(member *discordgo.Member, present bool) {
+	if member == nil || member.User == nil || member.User.Bot {
+		return
+	}
+	go h.StandupService.SyncGuildMember(member.GuildID, member.User.ID, member.Roles, present)
+}
@@ -0,0 +1,59 @@
+package standup
+
+import (
+	"fmt"
+
+	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleStandupRole grants and revokes co-manager and observer roles, and hands a
+// standup over to a new manager. The service checks who may do what.
+func (h *StandupHandler) handleStandupRole(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	subCommand := intr.ApplicationCommandData().Options[0]
+
+	optMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subCommand.Options {
+		optMap[opt.Name] = opt
+	}
+
+	need := models.AccessManage
+	if subCommand.Name == "transfer" {
+		need = models.AccessOwn
+	}
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), need)
+	if !ok {
+		return
+	}
+
+	callerID := utils.ExtractUserID(intr)
+	admin := utils.IsServerAdmin(intr)
+	target := optMap["user"].UserValue(session)
+	if target.Bot {
+		utils.RespondWithError(session, intr.Interaction, "⛔ Bots can't hold a role on a standup.")
+		return
+	}
+
+	var err error
+	var msg string
+	switch subCommand.Name {
+	case "grant":
+		role := optMap["role"].StringValue()
+		err = h.StandupService.GrantRole(*standup, callerID, admin, target.ID, role)
+		msg = fmt.Sprintf("✅ <@%s> is now a **%s** of **%s**.", target.ID, models.RoleName(role), standup.Name)
+	case "revoke":
+		err = h.StandupService.RevokeRole(*standup, callerID, admin, target.ID)
+		msg = fmt.Sprintf("✅ <@%s> no longer has a role on **%s**.", target.ID, standup.Name)
+	case "transfer":
+		err = h.StandupService.TransferOwnership(*standup, callerID, admin, target.ID)
+		msg = fmt.Sprintf("👑 <@%s> now manages **%s**. <@%s> stays on as a co-manager.",
+			target.ID, standup.Name, standup.ManagerID)
+	}
+
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+	utils.RespondWithMessage(session, intr, msg, true)
+}
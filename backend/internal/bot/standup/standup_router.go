@@ -35,6 +35,12 @@ func (h *StandupHandler) StandupRouter(session *discordgo.Session, intr *discord
 		case "delete-standup":
 			h.handleDeleteStandup(session, intr)
 			return true
+		case "pause-standup":
+			h.handlePauseStandup(session, intr)
+			return true
+		case "resume-standup":
+			h.handleResumeStandup(session, intr)
+			return true
 		case "add-member":
 			h.handleAddMember(session, intr)
 			return true
@@ -47,6 +53,24 @@ func (h *StandupHandler) StandupRouter(session *discordgo.Session, intr *discord
 		case "standup-info":
 			h.handleStandupInfo(session, intr)
 			return true
+		case "holidays":
+			h.handleHolidays(session, intr)
+			return true
+		case "set-schedule":
+			h.handleSetSchedule(session, intr)
+			return true
+		case "blockers":
+			h.handleBlockers(session, intr)
+			return true
+		case "standup-role":
+			h.handleStandupRole(session, intr)
+			return true
+		case "role-sync":
+			h.handleRoleSync(session, intr)
+			return true
+		case "template":
+			h.handleTemplate(session, intr)
+			return true
 		}
 
 	case discordgo.InteractionMessageComponent:
@@ -59,15 +83,42 @@ func (h *StandupHandler) StandupRouter(session *discordgo.Session, intr *discord
 			return true
 
 		} else if strings.HasPrefix(customID, "open_standup_modal_") {
-			idStr := strings.TrimPrefix(customID, "open_standup_modal_")
-			h.openSingleAnswerModal(session, intr, idStr, 0)
+			var standupID uint
+			fmt.Sscanf(customID, "open_standup_modal_%d", &standupID)
+			h.beginAnswers(session, intr, standupID, 0)
+			return true
+
+		} else if strings.HasPrefix(customID, "edit_report_") {
+			var historyID uint
+			fmt.Sscanf(customID, "edit_report_%d", &historyID)
+			h.handleEditReport(session, intr, historyID)
+			return true
+
+		} else if strings.HasPrefix(customID, "retract_report_") {
+			var historyID uint
+			fmt.Sscanf(customID, "retract_report_%d", &historyID)
+			h.handleRetractReport(session, intr, historyID)
+			return true
+
+		} else if strings.HasPrefix(customID, "resolve_blocker_") {
+			var blockerID uint
+			fmt.Sscanf(customID, "resolve_blocker_%d", &blockerID)
+			h.handleResolveBlocker(session, intr, blockerID)
 			return true
 
 		} else if strings.HasPrefix(customID, "continue_standup_") {
 			var standupID uint
 			var qIndex int
 			fmt.Sscanf(customID, "continue_standup_%d_%d", &standupID, &qIndex)
-			h.openSingleAnswerModal(session, intr, fmt.Sprintf("%d", standupID), qIndex)
+			h.askQuestion(session, intr, standupID, qIndex)
+			return true
+
+		} else if strings.HasPrefix(customID, "standup_choice_") {
+			var standupID uint
+			var qIndex int
+			var value string
+			fmt.Sscanf(customID, "standup_choice_%d_%d_%s", &standupID, &qIndex, &value)
+			h.handleChoiceAnswer(session, intr, standupID, qIndex, value)
 			return true
 
 		} else if customID == "select_standup_join" {
@@ -114,7 +165,7 @@ func (h *StandupHandler) StandupRouter(session *discordgo.Session, intr *discord
 			var standupID uint
 			var qIndex int
 			fmt.Sscanf(customID, "standup_answer_modal_%d_%d", &standupID, &qIndex)
-			h.handleSingleAnswerSubmit(session, intr, standupID, qIndex)
+			h.handleAnswerModalSubmit(session, intr, standupID, qIndex)
 			return true
 
 		} else if strings.HasPrefix(customID, "edit_single_q_") {
@@ -148,15 +199,33 @@ func (h *StandupHandler) handleAutocomplete(session *discordgo.Session, intr *di
 		data.Name == "remove-member" ||
 		data.Name == "edit-standup" ||
 		data.Name == "standup-info" ||
-		data.Name == "history" {
+		data.Name == "history" ||
+		data.Name == "holidays" ||
+		data.Name == "set-schedule" ||
+		data.Name == "blockers" ||
+		data.Name == "standup-role" ||
+		data.Name == "role-sync" ||
+		data.Name == "template" ||
+		data.Name == "create-standup" ||
+		data.Name == "pause-standup" ||
+		data.Name == "resume-standup" {
 
 		choices := []*discordgo.ApplicationCommandOptionChoice{}
+		focused := focusedOption(data.Options)
 		var typedValue string
-		for _, opt := range data.Options {
-			if opt.Focused {
-				typedValue = strings.ToLower(opt.StringValue())
-				break
-			}
+		if focused != nil {
+			typedValue = strings.ToLower(focused.StringValue())
+		}
+
+		if focused != nil && focused.Name == "calendar" {
+			h.respondCalendarChoices(session, intr, typedValue)
+			return true
+		}
+
+		if focused != nil && focused.Name == "template" {
+			deleting := data.Name == "template" && len(data.Options) > 0 && data.Options[0].Name == "delete"
+			h.respondTemplateChoices(session, intr, typedValue, deleting)
+			return true
 		}
 
 		userID := utils.ExtractUserID(intr)
@@ -165,7 +234,18 @@ func (h *StandupHandler) handleAutocomplete(session *discordgo.Session, intr *di
 		if utils.IsServerAdmin(intr) {
 			h.DB.Where("guild_id = ?", intr.GuildID).Find(&standups)
 		} else {
-			h.DB.Where("guild_id = ? AND manager_id = ?", intr.GuildID, userID).Find(&standups)
+			// Only suggest the standups the command would let the caller act on.
+			need := models.AccessManage
+			switch data.Name {
+			case "set-schedule", "history", "standup-info":
+				need = models.AccessOwnReports
+			case "blockers", "template":
+				need = models.AccessView
+			case "delete-standup":
+				need = models.AccessOwn
+			}
+			h.DB.Where("guild_id = ?", intr.GuildID).
+				Where(h.StandupService.AccessibleStandups(userID, need)).Find(&standups)
 		}
 
 		for _, st := range standups {
@@ -191,3 +271,18 @@ func (h *StandupHandler) handleAutocomplete(session *discordgo.Session, intr *di
 	}
 	return false
 }
+
+// focusedOption finds the option being typed, looking inside subcommands too.
+func focusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.
+	ApplicationCommandInteractionDataOption {
+
+	for _, opt := range options {
+		if opt.Focused {
+			return opt
+		}
+		if found := focusedOption(opt.Options); found != nil {
+			return found
+		}
+	}
+	return nil
+}
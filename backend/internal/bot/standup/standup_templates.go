@@ -0,0 +1,222 @@
+package standup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/services"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleTemplate lists, shows, saves and deletes standup templates.
+func (h *StandupHandler) handleTemplate(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	subCommand := intr.ApplicationCommandData().Options[0]
+
+	optMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subCommand.Options {
+		optMap[opt.Name] = opt
+	}
+
+	switch subCommand.Name {
+	case "list":
+		h.handleTemplateList(session, intr)
+	case "show":
+		h.handleTemplateShow(session, intr, optMap["template"].StringValue())
+	case "save":
+		h.handleTemplateSave(session, intr, optMap)
+	case "delete":
+		h.handleTemplateDelete(session, intr, optMap["template"].StringValue())
+	}
+}
+
+func (h *StandupHandler) handleTemplateList(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	templates, err := h.StandupService.GetTemplates(intr.GuildID, utils.ExtractUserID(intr))
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "❌ Failed to load templates.")
+		return
+	}
+
+	sections := map[string][]string{}
+	for _, tmpl := range templates {
+		line := fmt.Sprintf("**%s** (%d questions)", tmpl.Name, len(tmpl.Questions))
+		if tmpl.Description != "" {
+			line += " - " + tmpl.Description
+		}
+		sections[tmpl.Scope] = append(sections[tmpl.Scope], line)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📋 Standup Templates",
+		Color:       0x5865F2,
+		Description: "Pick one with the `template` option of `/create-standup`.",
+	}
+	for _, section := range []struct{ scope, title, empty string }{
+		{models.TemplateBuiltin, "📦 Built-in", ""},
+		{models.TemplateGuild, "🏠 This Server", "*None yet. Save one with `/template save`.*"},
+		{models.TemplatePersonal, "👤 Yours", "*None yet. Save one with `/template save`.*"},
+	} {
+		value := strings.Join(sections[section.scope], "\n")
+		if value == "" {
+			value = section.empty
+		} else if len(value) > 1000 {
+			value = fmt.Sprintf("*%d templates (List too long to display)*", len(sections[section.scope]))
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: section.title, Value: value})
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func (h *StandupHandler) handleTemplateShow(session *discordgo.Session, intr *discordgo.InteractionCreate, ref string) {
+	tmpl, err := h.StandupService.FindTemplate(intr.GuildID, utils.ExtractUserID(intr), ref)
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+
+	// Showing it as the standup it would create reuses the standup-info formatting.
+	var preview models.Standup
+	tmpl.Apply(&preview)
+
+	var qList strings.Builder
+	for i, q := range preview.Questions {
+		qList.WriteString(fmt.Sprintf("**%d.** %s%s\n", i+1, q, formatQuestionKind(preview.QuestionSpec(i))))
+	}
+
+	triggerTime := preview.Time
+	if triggerTime == "" {
+		triggerTime = "09:00"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📋 Template: %s", tmpl.Name),
+		Color:       0x5865F2,
+		Description: tmpl.Description,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "⏰ Trigger Time", Value: fmt.Sprintf("**%s**", triggerTime), Inline: true},
+			{Name: "📰 Report Mode", Value: formatReportMode(preview), Inline: true},
+			{Name: "🗓️ Cadence", Value: services.FormatCadence(preview), Inline: false},
+			{Name: "📝 Questions", Value: qList.String(), Inline: false},
+		},
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func (h *StandupHandler) handleTemplateSave(session *discordgo.Session, intr *discordgo.InteractionCreate,
+	optMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+
+	standup, ok := h.fetchAuthorizedStandup(session, intr, optMap["standup_name"].StringValue(), models.AccessView)
+	if !ok {
+		return
+	}
+
+	userID := utils.ExtractUserID(intr)
+	tmpl := services.TemplateFromStandup(*standup)
+	tmpl.Name = optMap["name"].StringValue()
+	tmpl.Scope = models.TemplatePersonal
+	tmpl.OwnerID = userID
+	tmpl.GuildID = intr.GuildID
+	tmpl.CreatedBy = userID
+	if opt, exists := optMap["scope"]; exists {
+		tmpl.Scope = opt.StringValue()
+	}
+	if opt, exists := optMap["description"]; exists {
+		tmpl.Description = opt.StringValue()
+	}
+
+	if !h.StandupService.CanEditTemplate(tmpl, userID, utils.IsServerAdmin(intr)) {
+		utils.RespondWithError(session, intr.Interaction,
+			"⛔ Only server admins and standup managers can save templates for the whole server.")
+		return
+	}
+
+	saved, err := h.StandupService.SaveTemplate(tmpl)
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+
+	who := "you"
+	if saved.Scope == models.TemplateGuild {
+		who = "everyone in this server"
+	}
+	utils.RespondWithMessage(session, intr, fmt.Sprintf("✅ Saved **%s** as the **%s** template for %s.",
+		standup.Name, saved.Name, who), true)
+}
+
+func (h *StandupHandler) handleTemplateDelete(session *discordgo.Session, intr *discordgo.InteractionCreate, ref string) {
+	userID := utils.ExtractUserID(intr)
+	tmpl, err := h.StandupService.FindTemplate(intr.GuildID, userID, ref)
+	if err != nil {
+		utils.RespondWithError(session, intr.Interaction, "⛔ "+err.Error()+".")
+		return
+	}
+	if tmpl.Scope == models.TemplateBuiltin {
+		utils.RespondWithError(session, intr.Interaction, "⛔ Built-in templates can't be deleted.")
+		return
+	}
+	if !h.StandupService.CanEditTemplate(*tmpl, userID, utils.IsServerAdmin(intr)) {
+		utils.RespondWithError(session, intr.Interaction, "⛔ You don't have permission to delete this template.")
+		return
+	}
+
+	if err := h.StandupService.DeleteTemplate(tmpl.ID); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "❌ Failed to delete the template.")
+		return
+	}
+	utils.RespondWithMessage(session, intr, fmt.Sprintf("🗑️ Deleted the **%s** template.", tmpl.Name), true)
+}
+
+// respondTemplateChoices suggests the templates the caller can use, or only the ones they
+// can delete.
+func (h *StandupHandler) respondTemplateChoices(session *discordgo.Session,
+	intr *discordgo.InteractionCreate, typedValue string, editableOnly bool) {
+
+	userID := utils.ExtractUserID(intr)
+	templates, _ := h.StandupService.GetTemplates(intr.GuildID, userID)
+	admin := utils.IsServerAdmin(intr)
+
+	labels := map[string]string{
+		models.TemplateBuiltin:  "built-in",
+		models.TemplateGuild:    "server",
+		models.TemplatePersonal: "yours",
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, tmpl := range templates {
+		if editableOnly && !h.StandupService.CanEditTemplate(tmpl, userID, admin) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(tmpl.Name), typedValue) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  fmt.Sprintf("%s (%s)", tmpl.Name, labels[tmpl.Scope]),
+				Value: tmpl.Ref(),
+			})
+		}
+	}
+	if len(choices) > 25 {
+		choices = choices[:25]
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
@@ -2,6 +2,7 @@ package standup
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
 	"github.com/Gurkunwar/asyncflow/internal/models"
@@ -38,11 +39,12 @@ func (h *StandupHandler) handleHistory(session *discordgo.Session, intr *discord
         return
     }
 
-    if standup.ManagerID != callerID && targetUser.ID != callerID {
+    if targetUser.ID != callerID &&
+        !h.StandupService.Authorize(standup, callerID, utils.IsServerAdmin(intr), models.AccessView) {
         session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
             Type: discordgo.InteractionResponseChannelMessageWithSource,
             Data: &discordgo.InteractionResponseData{
-                Content: "⛔ You can only view your own history, or history for teams you manage.",
+                Content: "⛔ You can only view your own history, or history for teams you manage or observe.",
                 Flags:   discordgo.MessageFlagsEphemeral,
             },
         })
@@ -68,13 +70,10 @@ func (h *StandupHandler) handleHistory(session *discordgo.Session, intr *discord
         var fields []*discordgo.MessageEmbedField
 
         for i, answer := range hist.Answers {
-            questionText := "Update"
-            if i < len(standup.Questions) {
-                questionText = standup.Questions[i]
-            }
+            question := hist.AskedQuestion(standup, i)
             fields = append(fields, &discordgo.MessageEmbedField{
-                Name:   questionText,
-                Value:  "👉 " + answer,
+                Name:   question.Text,
+                Value:  "👉 " + question.Spec().Display(answer),
                 Inline: false,
             })
         }
@@ -94,4 +93,64 @@ func (h *StandupHandler) handleHistory(session *discordgo.Session, intr *discord
             Flags:   discordgo.MessageFlagsEphemeral,
         },
     })
-}
\ No newline at end of file
+}
+func (h *StandupHandler) handleSetSchedule(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	optMap := utils.ParseCommandOptions(intr)
+	standupName := optMap["standup_name"].StringValue()
+	callerID := utils.ExtractUserID(intr)
+
+	var standup models.Standup
+	if err := h.DB.Where("guild_id = ? AND name = ?", intr.GuildID, standupName).
+		First(&standup).Error; err != nil {
+		utils.RespondWithError(session, intr.Interaction,
+			fmt.Sprintf("Standup named **%s** not found.", standupName))
+		return
+	}
+
+	targetID := callerID
+	if opt, ok := optMap["user"]; ok {
+		targetID = opt.UserValue(session).ID
+	}
+	if targetID != callerID && !h.authorizeStandup(session, intr, standup, models.AccessManage) {
+		return
+	}
+
+	override := h.StandupService.ParticipantOverride(standup.ID, targetID)
+	if opt, ok := optMap["time"]; ok {
+		override.Time = opt.StringValue()
+		if strings.EqualFold(override.Time, "default") {
+			override.Time = ""
+		}
+	}
+	if opt, ok := optMap["days"]; ok {
+		override.Days = opt.StringValue()
+		if strings.EqualFold(override.Days, "default") {
+			override.Days = ""
+		}
+	}
+
+	if err := h.StandupService.SetParticipantOverride(standup.ID, targetID, override); err != nil {
+		utils.RespondWithError(session, intr.Interaction, "Could not update the schedule: "+err.Error())
+		return
+	}
+
+	override = h.StandupService.ParticipantOverride(standup.ID, targetID)
+	effectiveTime, effectiveDays := standup.Time, standup.Days
+	if override.Time != "" {
+		effectiveTime = override.Time
+	}
+	if override.Days != "" {
+		effectiveDays = override.Days
+	}
+	if effectiveDays == "" {
+		effectiveDays = "Monday-Friday"
+	}
+	personal := fmt.Sprintf("**%s** on **%s**", effectiveTime, strings.ReplaceAll(effectiveDays, ",", ", "))
+
+	who := "Your"
+	if targetID != callerID {
+		who = fmt.Sprintf("<@%s>'s", targetID)
+	}
+	utils.RespondWithMessage(session, intr, fmt.Sprintf("✅ %s **%s** schedule is now %s (local time).",
+		who, standup.Name, personal), true)
+}
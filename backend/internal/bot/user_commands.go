@@ -3,6 +3,7 @@ package bot
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/Gurkunwar/asyncflow/internal/bot/utils"
 	"github.com/Gurkunwar/asyncflow/internal/models"
@@ -15,16 +16,25 @@ func (h *BotHanlder) handleHelp(session *discordgo.Session, intr *discordgo.Inte
 		"**👤 User Commands**\n" +
 		"`/start` - Manually trigger your daily standup form.\n" +
 		"`/history` - View past standup reports.\n" +
+		"`/blockers` - See open blockers and how long they've been waiting.\n" +
 		"`/timezone` - Set your local timezone so reminders trigger at your morning.\n" +
+		"`/set-schedule` - Use your own trigger time or days for a standup.\n" +
+		"`/answer-mode` - Answer standups with forms, or by simply replying to my DMs.\n" +
+		"`/ooo` - Register vacation days so standups record you as out of office instead of pinging you.\n" +
 		"`/poll` - 📊 Create a native poll for your team instantly.\n" +
 		"`/delete-my-data` - Permanently delete your profile and leave all standups.\n" +
 		"> *💡 Tip: When you receive your automated DM, you can use the **Skip Today** button if you are out of office!*\n\n" +
-		"**🛠️ Manager Commands (Admin Only)**\n" +
-		"`/create-standup` - Create a new team standup.\n" +
+		"**🛠️ Manager Commands**\n" +
+		"`/create-standup` - Create a new team standup *(Admin only)*.\n" +
 		"`/edit-standup` - Edit Questions, Active Days, Trigger Time, and Report Channel.\n" +
 		"`/standup-info` - View all settings, members, and questions for a standup.\n" +
+		"`/pause-standup` / `/resume-standup` - Put a standup on hold, optionally until a date.\n" +
+		"`/holidays` - Add or import (.ics) holiday calendars a standup should skip.\n" +
 		"`/add-member` - Add a user to an existing standup.\n" +
 		"`/remove-member` - Remove a user from an existing standup.\n" +
+		"`/template` - List, save and delete reusable standup templates.\n" +
+		"`/role-sync` - Keep a standup's members in sync with Discord roles.\n" +
+		"`/standup-role` - Add co-managers and observers, or hand a standup to a new manager.\n" +
 		"`/delete-standup` - Permanently delete an existing standup team.\n\n" +
 		"**📋 Poll Management (Admin Only)**\n" +
 		"`/poll-list` - List all recent polls and get their IDs.\n" +
@@ -59,6 +69,7 @@ func (h *BotHanlder) handleDeleteMyData(session *discordgo.Session, intr *discor
 		session.ChannelMessageSend(intr.ChannelID, "❌ Failed to reset profile.")
 		return
 	}
+	h.StandupService.InvalidateUser(userID)
 
 	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -136,6 +147,7 @@ func (h *BotHanlder) handleTimezoneSelection(session *discordgo.Session, intr *d
 	h.DB.Where(models.UserProfile{UserID: userID}).FirstOrCreate(&profile)
 	profile.Timezone = selectedTZ
 	h.DB.Save(&profile)
+	h.StandupService.InvalidateUser(userID)
 
 	utils.UpdateMessage(session, intr, fmt.Sprintf("✅ Timezone set to `%s`!", selectedTZ), nil)
 
@@ -146,3 +158,148 @@ func (h *BotHanlder) handleTimezoneSelection(session *discordgo.Session, intr *d
 		log.Println("No pending standup state found after timezone selection.")
 	}
 }
+
+// handleAnswerMode switches between the form-based flow and chat mode, where each question
+// arrives as a DM and the user's next message is the answer.
+func (h *BotHanlder) handleAnswerMode(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	userID := utils.ExtractUserID(intr)
+	chatMode := intr.ApplicationCommandData().Options[0].StringValue() == "chat"
+
+	var profile models.UserProfile
+	h.DB.Where(models.UserProfile{UserID: userID}).FirstOrCreate(&profile)
+	profile.ChatMode = chatMode
+	h.DB.Save(&profile)
+
+	if !chatMode {
+		store.ClearChatSession(h.Redis, userID)
+		utils.RespondWithMessage(session, intr,
+			"✅ **Form mode on.** Your standups will open as forms and buttons.", true)
+		return
+	}
+
+	utils.RespondWithMessage(session, intr,
+		"✅ **Chat mode on.** I'll DM you each question; just reply with your answer.\n"+
+			"Reply `skip` to skip an optional question, `back` to redo the previous one, "+
+			"or `cancel` to stop.", true)
+}
+
+func (h *BotHanlder) handleOutOfOffice(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	userID := utils.ExtractUserID(intr)
+	subCommand := intr.ApplicationCommandData().Options[0]
+
+	optMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subCommand.Options {
+		optMap[opt.Name] = opt
+	}
+
+	switch subCommand.Name {
+	case "add":
+		entry := models.OutOfOffice{
+			UserID:    userID,
+			StartDate: optMap["start"].StringValue(),
+		}
+		entry.EndDate = entry.StartDate
+		if opt, ok := optMap["end"]; ok {
+			entry.EndDate = opt.StringValue()
+		}
+		if opt, ok := optMap["reason"]; ok {
+			entry.Reason = opt.StringValue()
+		}
+
+		scope := "all of your standups"
+		if opt, ok := optMap["standup_name"]; ok {
+			var standup models.Standup
+			if err := h.DB.Where("guild_id = ? AND name = ?", intr.GuildID, opt.StringValue()).
+				First(&standup).Error; err != nil {
+				utils.RespondWithError(session, intr.Interaction,
+					fmt.Sprintf("Standup named **%s** not found.", opt.StringValue()))
+				return
+			}
+			entry.StandupID = standup.ID
+			scope = fmt.Sprintf("**%s**", standup.Name)
+		}
+
+		created, err := h.StandupService.AddOutOfOffice(entry)
+		if err != nil {
+			utils.RespondWithError(session, intr.Interaction, "Could not save that: "+err.Error())
+			return
+		}
+
+		utils.RespondWithMessage(session, intr, fmt.Sprintf(
+			"🌴 **Out of office saved** (ID `%d`): %s for %s.\nI won't ping you on those days.",
+			created.ID, formatOutOfOfficeRange(*created), scope), true)
+
+	case "list":
+		entries, err := h.StandupService.GetOutOfOffice(userID)
+		if err != nil || len(entries) == 0 {
+			utils.RespondWithMessage(session, intr, "📭 You have no upcoming out-of-office days.", true)
+			return
+		}
+
+		var lines []string
+		for _, entry := range entries {
+			scope := "All standups"
+			if entry.StandupID != 0 {
+				var standup models.Standup
+				if h.DB.First(&standup, entry.StandupID).Error == nil {
+					scope = standup.Name
+				}
+			}
+			line := fmt.Sprintf("`%d` • %s • %s", entry.ID, formatOutOfOfficeRange(entry), scope)
+			if entry.Reason != "" {
+				line += " • " + entry.Reason
+			}
+			lines = append(lines, line)
+		}
+
+		utils.RespondWithMessage(session, intr,
+			"🌴 **Your Out-of-Office Days**\n"+strings.Join(lines, "\n"), true)
+
+	case "cancel":
+		id := uint(optMap["id"].IntValue())
+		if err := h.StandupService.DeleteOutOfOffice(userID, id); err != nil {
+			utils.RespondWithError(session, intr.Interaction, "Could not remove that entry: "+err.Error())
+			return
+		}
+		utils.RespondWithMessage(session, intr, fmt.Sprintf("✅ Out-of-office entry `%d` removed.", id), true)
+	}
+}
+
+func (h *BotHanlder) handleOutOfOfficeAutocomplete(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var typedValue string
+	for _, sub := range intr.ApplicationCommandData().Options {
+		for _, opt := range sub.Options {
+			if opt.Focused {
+				typedValue = strings.ToLower(opt.StringValue())
+			}
+		}
+	}
+
+	var profile models.UserProfile
+	h.DB.Preload("Standups", "guild_id = ?", intr.GuildID).
+		Where("user_id = ?", utils.ExtractUserID(intr)).First(&profile)
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, st := range profile.Standups {
+		if strings.Contains(strings.ToLower(st.Name), typedValue) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: st.Name, Value: st.Name})
+		}
+	}
+	if len(choices) > 25 {
+		choices = choices[:25]
+	}
+
+	session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+func formatOutOfOfficeRange(entry models.OutOfOffice) string {
+	if entry.StartDate == entry.EndDate {
+		return entry.StartDate
+	}
+	return fmt.Sprintf("%s → %s", entry.StartDate, entry.EndDate)
+}
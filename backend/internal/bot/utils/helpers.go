@@ -1,9 +1,9 @@
 package utils
 
 import (
-	"fmt"
 	"time"
 
+	"github.com/Gurkunwar/asyncflow/internal/services"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -35,15 +35,7 @@ func IsServerAdmin(intr *discordgo.InteractionCreate) bool {
 		return false
 	}
 
-	return intr.Member.Permissions&discordgo.PermissionAdministrator != 0
-}
-
-func FormatLocalTime(dbTimeStr string, userTZ string) string {
-	if userTZ == "" {
-		return fmt.Sprintf("**%s (Your Local Time)**\n> ⚠️ *Wait! You haven't set a timezone yet. Run `/timezone` so this triggers at your actual morning!*", dbTimeStr)
-	}
-
-	return fmt.Sprintf("**%s** (%s)", dbTimeStr, userTZ)
+	return services.IsServerAdmin(intr.Member.Permissions)
 }
 
 func GetUserLocalTime(tz string) time.Time {
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"fmt"
+	"log"
 	"os"
 
 	"github.com/Gurkunwar/asyncflow/internal/models"
@@ -16,15 +18,141 @@ func InitDB() (*gorm.DB, error) {
 		return nil, err
 	}
 
+	// The participants join table carries per-member schedule overrides.
+	db.SetupJoinTable(&models.Standup{}, "Participants", &models.StandupParticipant{})
+	db.SetupJoinTable(&models.UserProfile{}, "Standups", &models.StandupParticipant{})
+
+	dedupeStandupHistories(db)
+
 	db.AutoMigrate(
 		&models.Guild{},
 		&models.UserProfile{},
 		&models.StandupHistory{},
 		&models.Standup{},
+		&models.StandupSchedule{},
+		&models.StandupSummary{},
+		&models.StandupDigest{},
+		&models.StandupThread{},
+		&models.OutOfOffice{},
+		&models.HolidayCalendar{},
+		&models.Holiday{},
+		&models.StandupAnswer{},
+		&models.Blocker{},
+		&models.StandupMember{},
+		&models.StandupTemplate{},
 
 		&models.Poll{},
 		&models.PollOption{},
 		&models.PollVote{},
 	)
+
+	backfillQuestionSnapshots(db)
 	return db, nil
-}
\ No newline at end of file
+}
+
+// markerAnswers are the stored answers of skipped and out-of-office days.
+func markerAnswers() []string {
+	return []string{
+		fmt.Sprintf("[%q]", models.SkippedAnswer),
+		fmt.Sprintf("[%q]", models.OutOfOfficeAnswer),
+	}
+}
+
+// dedupeStandupHistories removes the duplicate reports older versions could store for the
+// same member, standup and date, so the unique index on them can be created. A real
+// report wins over a skipped or out-of-office marker, then the latest one.
+func dedupeStandupHistories(db *gorm.DB) {
+	if !db.Migrator().HasTable(&models.StandupHistory{}) {
+		return
+	}
+
+	var duplicates []uint
+	if err := db.Raw(`SELECT id FROM (
+		SELECT id, ROW_NUMBER() OVER (
+			PARTITION BY user_id, standup_id, date
+			ORDER BY (answers IN ?), id DESC
+		) AS rank
+		FROM standup_histories WHERE deleted_at IS NULL
+	) ranked WHERE rank > 1`, markerAnswers()).Scan(&duplicates).Error; err != nil {
+		log.Printf("Warning: Failed to look for duplicate standup reports: %v", err)
+		return
+	}
+	if len(duplicates) == 0 {
+		return
+	}
+
+	for _, dependent := range []interface{}{&models.StandupAnswer{}, &models.Blocker{}} {
+		if db.Migrator().HasTable(dependent) {
+			db.Where("history_id IN ?", duplicates).Delete(dependent)
+		}
+	}
+	if err := db.Unscoped().Delete(&models.StandupHistory{}, duplicates).Error; err != nil {
+		log.Printf("Warning: Failed to remove duplicate standup reports: %v", err)
+		return
+	}
+	log.Printf("🧹 Removed %d duplicate standup reports", len(duplicates))
+}
+
+// backfillQuestionSnapshots gives questions from before stable IDs an ID, typed answers
+// the ID of the question that has their text, and reports from before question
+// snapshots a snapshot of the questions they most likely answered: the standup's
+// questions as they are now, which is the best that is still known.
+func backfillQuestionSnapshots(db *gorm.DB) {
+	var standups []models.Standup
+	if err := db.Find(&standups).Error; err != nil {
+		log.Printf("Warning: Failed to load standups for question IDs: %v", err)
+		return
+	}
+
+	byID := make(map[uint]models.Standup, len(standups))
+	for _, standup := range standups {
+		missing := len(standup.QuestionSpecs) != len(standup.Questions)
+		specs := make([]models.QuestionSpec, len(standup.Questions))
+		for i := range specs {
+			specs[i] = standup.QuestionSpec(i)
+			if specs[i].ID == "" {
+				specs[i].ID = models.NewQuestionID()
+				missing = true
+			}
+		}
+		if missing {
+			standup.QuestionSpecs = specs
+			if err := db.Model(&standup).Select("QuestionSpecs").Updates(&standup).Error; err != nil {
+				log.Printf("Warning: Failed to assign question IDs for standup %d: %v", standup.ID, err)
+			}
+		}
+		byID[standup.ID] = standup
+
+		for i, question := range standup.Questions {
+			if err := db.Model(&models.StandupAnswer{}).
+				Where("standup_id = ? AND question = ? AND (question_id IS NULL OR question_id = '')", standup.ID, question).
+				Update("question_id", specs[i].ID).Error; err != nil {
+				log.Printf("Warning: Failed to link answers to question IDs for standup %d: %v", standup.ID, err)
+				break
+			}
+		}
+	}
+
+	var histories []models.StandupHistory
+	result := db.Where("(questions IS NULL OR questions = 'null') AND answers NOT IN ?", markerAnswers()).
+		FindInBatches(&histories, 500, func(tx *gorm.DB, batch int) error {
+			for _, history := range histories {
+				standup, ok := byID[history.StandupID]
+				if !ok {
+					continue
+				}
+				history.Questions = models.SnapshotQuestions(standup, history.Asked, len(history.Answers))
+				if err := db.Model(&history).Select("Questions").Updates(&history).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if result.Error != nil {
+		log.Printf("Warning: Failed to snapshot questions of past reports: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("🗂️ Snapshotted the questions of %d past reports", result.RowsAffected)
+	}
+}
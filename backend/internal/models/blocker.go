@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	BlockerOpen     = "open"
+	BlockerResolved = "resolved"
+)
+
+// Blocker is raised from a report whose answer to the standup's blocker question says
+// something is in the way. UserID is the member who reported it and owns it until it is
+// resolved, by them or by the standup's manager.
+type Blocker struct {
+	ID         uint       `gorm:"primarykey" json:"id"`
+	StandupID  uint       `gorm:"index" json:"standup_id"`
+	HistoryID  uint       `gorm:"index" json:"history_id"`
+	UserID     string     `gorm:"index" json:"user_id"`
+	Text       string     `json:"text"`
+	Status     string     `gorm:"index;default:open" json:"status"`
+	ResolvedBy string     `json:"resolved_by"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Age is how long the blocker has been open, or was open before it got resolved.
+func (b Blocker) Age(now time.Time) time.Duration {
+	if b.ResolvedAt != nil {
+		now = *b.ResolvedAt
+	}
+	return now.Sub(b.CreatedAt)
+}
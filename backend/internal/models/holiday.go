@@ -0,0 +1,20 @@
+package models
+
+import "gorm.io/gorm"
+
+// HolidayCalendar is a named set of dates, shared across a guild, that can be attached
+// to standups so nobody is prompted on those days.
+type HolidayCalendar struct {
+	gorm.Model
+	GuildID  string    `gorm:"index" json:"guild_id"`
+	Name     string    `json:"name"`
+	Holidays []Holiday `gorm:"foreignKey:CalendarID;constraint:OnDelete:CASCADE" json:"holidays"`
+}
+
+// Holiday is a single day off, as a YYYY-MM-DD date in each participant's own timezone.
+type Holiday struct {
+	ID         uint   `gorm:"primarykey" json:"id"`
+	CalendarID uint   `gorm:"uniqueIndex:idx_holiday_calendar_date" json:"calendar_id"`
+	Date       string `gorm:"uniqueIndex:idx_holiday_calendar_date" json:"date"`
+	Name       string `json:"name"`
+}
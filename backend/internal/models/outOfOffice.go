@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// OutOfOffice is an inclusive range of dates, in the user's own timezone, during which
+// they aren't prompted. A zero StandupID covers every standup they belong to.
+type OutOfOffice struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	UserID    string    `gorm:"index" json:"user_id"`
+	StandupID uint      `json:"standup_id"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
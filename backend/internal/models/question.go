@@ -0,0 +1,201 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Question kinds. Text is the original required paragraph answer and is what every
+// question without a spec falls back to.
+const (
+	QuestionText   = "text"
+	QuestionShort  = "short"
+	QuestionSelect = "select"
+	QuestionYesNo  = "yes_no"
+	QuestionScale  = "scale"
+)
+
+const (
+	ScaleMin = 1
+	ScaleMax = 5
+)
+
+// QuestionSpec describes how one entry of Standup.Questions is asked. Specs line up with
+// Questions by index; standups created before typed questions have none.
+type QuestionSpec struct {
+	// ID identifies the question for as long as it exists, whatever its position or
+	// wording, so reports can be matched to it after the questions are rearranged.
+	ID        string             `json:"id,omitempty"`
+	Type      string             `json:"type"`
+	Optional  bool               `json:"optional,omitempty"`
+	MinLength int                `json:"min_length,omitempty"`
+	MaxLength int                `json:"max_length,omitempty"`
+	Choices   []string           `json:"choices,omitempty"`
+	ShowIf    *QuestionCondition `json:"show_if,omitempty"`
+	// Blocker marks the question whose answers are tracked as blockers. At most one
+	// question per standup carries it.
+	Blocker bool `json:"blocker,omitempty"`
+	// CarryFrom links the question to another one whose answer in the user's previous
+	// report is offered as the starting point, e.g. yesterday's plan for "What did you
+	// accomplish yesterday?".
+	CarryFrom *int `json:"carry_from,omitempty"`
+}
+
+// NewQuestionID returns a random ID for a question. IDs are never reused, so reports made
+// before a question was removed can't be mistaken for answers to a later one.
+func NewQuestionID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// QuestionCondition makes a question a follow-up to an earlier one: it is only asked when
+// that question's answer is one of Answers, or was answered at all when Answers is empty.
+type QuestionCondition struct {
+	Question int      `json:"question"`
+	Answers  []string `json:"answers,omitempty"`
+}
+
+// Matches reports whether the earlier question's answer triggers the follow-up.
+func (c QuestionCondition) Matches(answer string, asked bool) bool {
+	if !asked || answer == "" {
+		return false
+	}
+	if len(c.Answers) == 0 {
+		return true
+	}
+	for _, want := range c.Answers {
+		if strings.EqualFold(strings.TrimSpace(want), answer) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTyped reports whether answers are picked from fixed values rather than typed out,
+// which is what makes them worth aggregating.
+func (q QuestionSpec) IsTyped() bool {
+	return q.Type == QuestionSelect || q.Type == QuestionYesNo || q.Type == QuestionScale
+}
+
+// Display renders a stored answer for reports. Markers such as SkippedAnswer pass
+// through unchanged.
+func (q QuestionSpec) Display(answer string) string {
+	if answer == "" {
+		return "*No answer*"
+	}
+	if _, err := strconv.Atoi(answer); err == nil && q.Type == QuestionScale {
+		return fmt.Sprintf("%s / %d", answer, ScaleMax)
+	}
+	return answer
+}
+
+// QuestionSpec returns how the i-th question is asked, defaulting to a required
+// free-text answer.
+func (s Standup) QuestionSpec(i int) QuestionSpec {
+	if i < len(s.QuestionSpecs) && s.QuestionSpecs[i].Type != "" {
+		return s.QuestionSpecs[i]
+	}
+	return QuestionSpec{Type: QuestionText}
+}
+
+// QuestionPosition returns the index of the question with the given ID, and false if the
+// standup no longer has it.
+func (s Standup) QuestionPosition(id string) (int, bool) {
+	for i := range s.Questions {
+		if s.QuestionSpec(i).ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// BlockerQuestion returns the index of the question asking about blockers, if one is
+// marked.
+func (s Standup) BlockerQuestion() (int, bool) {
+	for i := range s.Questions {
+		if s.QuestionSpec(i).Blocker {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// AskedQuestion records a question as it was asked in one report: its ID, wording and
+// answer type at the time, so the report still reads right after the standup's questions
+// are reordered, reworded or removed.
+type AskedQuestion struct {
+	ID   string `json:"id,omitempty"`
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// Spec is enough of the question's spec to display its answer.
+func (q AskedQuestion) Spec() QuestionSpec {
+	return QuestionSpec{ID: q.ID, Type: q.Type}
+}
+
+// SnapshotQuestions captures the standup's current questions in the order a report
+// answered them. asked is as in StandupHistory.Asked.
+func SnapshotQuestions(standup Standup, asked []int, answers int) []AskedQuestion {
+	snapshot := make([]AskedQuestion, 0, answers)
+	for i := 0; i < answers; i++ {
+		q := i
+		if i < len(asked) {
+			q = asked[i]
+		}
+		question := AskedQuestion{Text: "Update", Type: QuestionText}
+		if q < len(standup.Questions) {
+			spec := standup.QuestionSpec(q)
+			question = AskedQuestion{ID: spec.ID, Text: standup.Questions[q], Type: spec.Type}
+		}
+		snapshot = append(snapshot, question)
+	}
+	return snapshot
+}
+
+// AskedQuestion returns the question the i-th answer was given to. Reports store what
+// was asked; rows the snapshot migration hasn't reached fall back to the standup's
+// current questions.
+func (h StandupHistory) AskedQuestion(standup Standup, i int) AskedQuestion {
+	if len(h.Questions) == len(h.Answers) && i < len(h.Questions) {
+		return h.Questions[i]
+	}
+	return SnapshotQuestions(standup, h.Asked, len(h.Answers))[i]
+}
+
+// AnswerToQuestion returns the answer given to what is now the standup's q-th question,
+// matched by question ID so it survives reordering, and whether it was asked at all.
+func (h StandupHistory) AnswerToQuestion(standup Standup, q int) (string, bool) {
+	id := standup.QuestionSpec(q).ID
+	if id == "" || len(h.Questions) != len(h.Answers) {
+		return h.AnswerTo(q)
+	}
+	for i, question := range h.Questions {
+		if question.ID == id {
+			return h.Answers[i], true
+		}
+	}
+	return "", false
+}
+
+// StandupAnswer is one select, yes/no or scale answer from a submission, stored next to
+// its history row so answers can be aggregated per question.
+type StandupAnswer struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	HistoryID     uint      `gorm:"index" json:"history_id"`
+	StandupID     uint      `gorm:"index:idx_answer_standup_date" json:"standup_id"`
+	Date          string    `gorm:"index:idx_answer_standup_date" json:"date"`
+	UserID        string    `json:"user_id"`
+	QuestionIndex int       `json:"question_index"`
+	QuestionID    string    `gorm:"index" json:"question_id"`
+	Question      string    `json:"question"`
+	Type          string    `json:"type"`
+	Value         string    `json:"value"`
+	Score         *int      `json:"score"`
+	CreatedAt     time.Time `json:"created_at"`
+}
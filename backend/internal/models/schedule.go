@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type StandupSchedule struct {
+	ID            uint      `gorm:"primarykey"`
+	StandupID     uint      `gorm:"uniqueIndex:idx_schedule_standup_user"`
+	UserID        string    `gorm:"uniqueIndex:idx_schedule_standup_user"`
+	NextFireAt    time.Time `gorm:"index"`
+	LastFiredAt   *time.Time
+	RemindersSent int
+	UpdatedAt     time.Time
+}
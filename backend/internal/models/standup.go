@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
@@ -12,18 +14,189 @@ type Standup struct {
 	ManagerID       string         `json:"manager_id"`
 	ReportChannelID string         `json:"report_channel_id"`
 	Questions       pq.StringArray `gorm:"type:text[]" json:"questions"`
+	QuestionSpecs   []QuestionSpec `gorm:"type:text;serializer:json" json:"question_specs"`
 	Time            string         `default:"09:00" json:"time"`
 	Days            string
+	Timezone        string         `json:"timezone"`
+	ScheduleType    string         `json:"schedule_type"`
+	IntervalWeeks   int            `json:"interval_weeks"`
+	AnchorDate      string         `json:"anchor_date"`
+	MonthWeek       int            `json:"month_week"`
+	CronExpr        string         `json:"cron_expr"`
+	ReminderOffsets pq.Int64Array  `gorm:"type:integer[]" json:"reminder_offsets"`
+	MaxReminders    int            `json:"max_reminders"`
+	CutoffTime      string         `json:"cutoff_time"`
+	ReportMode      string         `json:"report_mode"`
+	DigestTime      string         `json:"digest_time"`
+	DailyThread     bool           `json:"daily_thread"`
+	// SyncRoleIDs are Discord roles whose holders are kept in the standup automatically.
+	SyncRoleIDs     pq.StringArray `gorm:"type:text[]" json:"sync_role_ids"`
+	// PausedAt is set while the standup is on hold. Nothing fires until ResumeOn, a
+	// YYYY-MM-DD date in each participant's own timezone, or until it is resumed by hand
+	// when ResumeOn is empty.
+	PausedAt        *time.Time     `json:"paused_at"`
+	PausedBy        string         `json:"paused_by"`
+	PauseReason     string         `json:"pause_reason"`
+	ResumeOn        string         `json:"resume_on"`
 	Participants    []UserProfile `gorm:"many2many:standup_participants;" json:"participants"`
+	HolidayCalendars []HolidayCalendar `gorm:"many2many:standup_holiday_calendars;" json:"holiday_calendars"`
+}
+
+// StandupParticipant is the standup_participants join row. Time and Days, when set,
+// replace the standup's own trigger time and active days for this one member. ViaRole
+// marks members added because they hold one of the standup's synced roles, the only ones
+// role syncing ever removes.
+type StandupParticipant struct {
+	StandupID     uint   `gorm:"primaryKey" json:"standup_id"`
+	UserProfileID uint   `gorm:"primaryKey" json:"user_profile_id"`
+	Time          string `json:"time"`
+	Days          string `json:"days"`
+	ViaRole       bool   `json:"via_role"`
 }
 
+// StandupHistory is one member's report, or skipped/out-of-office marker, for a standup
+// on a date. There is at most one per member, standup and date.
 type StandupHistory struct {
 	gorm.Model
-	UserID    string   `gorm:"index" json:"user_id"`
-	StandupID uint     `gorm:"index" json:"standup_id"`
+	UserID    string   `gorm:"index;uniqueIndex:idx_history_user_standup_date,where:deleted_at IS NULL" json:"user_id"`
+	StandupID uint     `gorm:"index;uniqueIndex:idx_history_user_standup_date,where:deleted_at IS NULL" json:"standup_id"`
 	Standup   Standup  `gorm:"foreignKey:StandupID" json:"standup"`
-	Date      string   `gorm:"index" json:"date"`
+	Date      string   `gorm:"index;uniqueIndex:idx_history_user_standup_date,where:deleted_at IS NULL" json:"date"`
 	Answers   []string `gorm:"type:text;serializer:json" json:"answers"`
+	Asked     []int    `gorm:"type:text;serializer:json" json:"asked"`
+	// Questions snapshots what each answer was given to, lined up with Answers.
+	Questions []AskedQuestion `gorm:"type:text;serializer:json" json:"questions"`
+	ChannelID string   `json:"channel_id"`
+	MessageID string   `json:"message_id"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+// IsMarker reports whether the row records a skipped or out-of-office day instead of a
+// submitted report.
+func (h StandupHistory) IsMarker() bool {
+	return len(h.Answers) == 1 && (h.Answers[0] == SkippedAnswer || h.Answers[0] == OutOfOfficeAnswer)
+}
+
+// AnswerTo returns the answer given to question q, and whether it was asked at all.
+func (h StandupHistory) AnswerTo(q int) (string, bool) {
+	for i, answer := range h.Answers {
+		if h.QuestionIndex(i) == q {
+			return answer, true
+		}
+	}
+	return "", false
+}
+
+// QuestionIndex returns which of the standup's questions the i-th answer belongs to.
+// Asked is only recorded when follow-up questions could be left out; older rows answer
+// every question in order.
+func (h StandupHistory) QuestionIndex(i int) int {
+	if i < len(h.Asked) {
+		return h.Asked[i]
+	}
+	return i
+}
+
+// CurrentIndex returns where the question the i-th answer was given to now sits among
+// the standup's questions, and false if it has been removed.
+func (h StandupHistory) CurrentIndex(standup Standup, i int) (int, bool) {
+	if id := h.AskedQuestion(standup, i).ID; id != "" {
+		return standup.QuestionPosition(id)
+	}
+	q := h.QuestionIndex(i)
+	return q, q < len(standup.Questions)
+}
+
+// AlignedAnswers lays the answers out by the standup's current question positions,
+// leaving blanks for follow-ups that weren't asked. Answers to questions that have since
+// been removed are left out.
+func (h StandupHistory) AlignedAnswers(standup Standup) []string {
+	var aligned []string
+	for i, answer := range h.Answers {
+		q, ok := h.CurrentIndex(standup, i)
+		if !ok {
+			continue
+		}
+		for len(aligned) <= q {
+			aligned = append(aligned, "")
+		}
+		aligned[q] = answer
+	}
+	return aligned
+}
+
+// A standup's cadence decides which days it runs on. Weekly (the default, also used by
+// rows that predate cadences) runs on every one of Days; the others narrow Days further
+// or, for cron, replace it.
+const (
+	ScheduleWeekly   = "weekly"
+	ScheduleInterval = "interval"
+	ScheduleMonthly  = "monthly"
+	ScheduleCron     = "cron"
+)
+
+const (
+	ReportModeIndividual = "individual"
+	ReportModeDigest     = "digest"
+)
+
+// IsDigest reports whether answers are held back for one consolidated daily message
+// instead of being posted per person as they arrive.
+func (s Standup) IsDigest() bool {
+	return s.ReportMode == ReportModeDigest
+}
+
+// IsPaused reports whether the standup is on hold.
+func (s Standup) IsPaused() bool {
+	return s.PausedAt != nil
+}
+
+// PausedOn reports whether the standup is still on hold on the local date.
+func (s Standup) PausedOn(date string) bool {
+	return s.IsPaused() && (s.ResumeOn == "" || date < s.ResumeOn)
+}
+
+// UsesDailyThread reports whether the day's reports go into a thread of their own. A
+// digest is already a single message, so it never does.
+func (s Standup) UsesDailyThread() bool {
+	return s.DailyThread && !s.IsDigest()
+}
+
+// SkippedAnswer is what the "Skip Today" button records as the day's only answer.
+const SkippedAnswer = "Skipped / OOO"
+
+// OutOfOfficeAnswer is recorded instead of prompting a participant whose OOO calendar
+// covers the day.
+const OutOfOfficeAnswer = "OOO"
+
+// StandupSummary marks that the end-of-day "missing reports" embed was posted for a date.
+type StandupSummary struct {
+	gorm.Model
+	StandupID uint   `gorm:"uniqueIndex:idx_summary_standup_date"`
+	Date      string `gorm:"uniqueIndex:idx_summary_standup_date"`
+	ChannelID string
+	MessageID string
+}
+
+// StandupDigest tracks the consolidated message(s) posted for a digest-mode standup on a
+// given date, so late submissions can be folded in by editing them.
+type StandupDigest struct {
+	gorm.Model
+	StandupID  uint           `gorm:"uniqueIndex:idx_digest_standup_date"`
+	Date       string         `gorm:"uniqueIndex:idx_digest_standup_date"`
+	ChannelID  string
+	MessageIDs pq.StringArray `gorm:"type:text[]"`
+}
+
+// StandupThread is the discussion thread opened for a standup's reports on a given date,
+// started from the summary message it keeps up to date in the report channel.
+type StandupThread struct {
+	gorm.Model
+	StandupID        uint   `gorm:"uniqueIndex:idx_thread_standup_date"`
+	Date             string `gorm:"uniqueIndex:idx_thread_standup_date"`
+	ChannelID        string
+	SummaryMessageID string
+	ThreadID         string
 }
 
 type StandupState struct {
@@ -31,4 +204,17 @@ type StandupState struct {
 	GuildID   string   `json:"guild_id"`
 	StandupID uint     `json:"standup_id"`
 	Answers   []string `json:"answers"`
+	Asked     []int    `json:"asked,omitempty"`
+	// EditingHistoryID is set while the user is revising an already submitted report.
+	EditingHistoryID uint `json:"editing_history_id,omitempty"`
+}
+
+// AnswerTo returns the answer given to question q, and whether it was asked at all.
+func (s StandupState) AnswerTo(q int) (string, bool) {
+	for i, asked := range s.Asked {
+		if asked == q && i < len(s.Answers) {
+			return s.Answers[i], true
+		}
+	}
+	return "", false
 }
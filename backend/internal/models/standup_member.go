@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Roles someone can hold on a standup. The manager is Standup.ManagerID and participants
+// are the standup's Participants; co-managers and observers are granted as StandupMember
+// rows.
+const (
+	RoleManager     = "manager"
+	RoleCoManager   = "co_manager"
+	RoleParticipant = "participant"
+	RoleObserver    = "observer"
+)
+
+// StandupMember grants a user a co-manager or observer role on a standup.
+type StandupMember struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	StandupID uint      `gorm:"uniqueIndex:idx_member_standup_user" json:"standup_id"`
+	UserID    string    `gorm:"uniqueIndex:idx_member_standup_user" json:"user_id"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Access is what someone may do with a standup. Each level includes the ones below it.
+type Access int
+
+const (
+	AccessNone Access = iota
+	// AccessOwnReports lets participants answer and look back at their own reports.
+	AccessOwnReports
+	// AccessView shows everyone's reports, stats and blockers.
+	AccessView
+	// AccessManage changes the schedule, questions and participants, and grants observers.
+	AccessManage
+	// AccessOwn deletes or hands over the standup and appoints co-managers.
+	AccessOwn
+)
+
+// RoleAccess is the access a role comes with.
+func RoleAccess(role string) Access {
+	switch role {
+	case RoleManager:
+		return AccessOwn
+	case RoleCoManager:
+		return AccessManage
+	case RoleObserver:
+		return AccessView
+	case RoleParticipant:
+		return AccessOwnReports
+	}
+	return AccessNone
+}
+
+// RoleName is how a role is shown in Discord and on the dashboard.
+func RoleName(role string) string {
+	switch role {
+	case RoleManager:
+		return "Manager"
+	case RoleCoManager:
+		return "Co-manager"
+	case RoleObserver:
+		return "Observer"
+	case RoleParticipant:
+		return "Participant"
+	}
+	return "None"
+}
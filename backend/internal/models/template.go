@@ -0,0 +1,88 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Where a template lives. Built-in templates ship with the bot; guild templates are
+// shared across a server and personal ones are only offered to the user who saved them.
+const (
+	TemplateBuiltin  = "builtin"
+	TemplateGuild    = "guild"
+	TemplatePersonal = "personal"
+)
+
+// StandupTemplate is a reusable starting point for new standups: their questions,
+// schedule, days and report mode.
+type StandupTemplate struct {
+	ID uint `gorm:"primarykey" json:"id"`
+	// Key names a built-in template. Stored templates have none.
+	Key           string         `gorm:"-" json:"key,omitempty"`
+	Scope         string         `json:"scope"`
+	GuildID       string         `gorm:"index" json:"guild_id,omitempty"`
+	OwnerID       string         `gorm:"index" json:"owner_id,omitempty"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	Questions     pq.StringArray `gorm:"type:text[]" json:"questions"`
+	QuestionSpecs []QuestionSpec `gorm:"type:text;serializer:json" json:"question_specs"`
+	Time          string         `json:"time"`
+	Days          string         `json:"days"`
+	ScheduleType  string         `json:"schedule_type"`
+	IntervalWeeks int            `json:"interval_weeks"`
+	MonthWeek     int            `json:"month_week"`
+	CronExpr      string         `json:"cron_expr"`
+	ReportMode    string         `json:"report_mode"`
+	DigestTime    string         `json:"digest_time"`
+	CreatedBy     string         `json:"created_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// Ref identifies the template in commands and API calls: "builtin:<key>" for built-in
+// templates and the ID for stored ones.
+func (t StandupTemplate) Ref() string {
+	if t.Scope == TemplateBuiltin {
+		return TemplateBuiltin + ":" + t.Key
+	}
+	return strconv.FormatUint(uint64(t.ID), 10)
+}
+
+// Apply fills in whatever the standup leaves unset from the template. Question IDs are
+// left blank so the new standup gets its own.
+func (t StandupTemplate) Apply(standup *Standup) {
+	if len(standup.Questions) == 0 {
+		standup.Questions = append(pq.StringArray{}, t.Questions...)
+		standup.QuestionSpecs = nil
+		for _, spec := range t.QuestionSpecs {
+			spec.ID = ""
+			standup.QuestionSpecs = append(standup.QuestionSpecs, spec)
+		}
+	}
+	if standup.Time == "" {
+		standup.Time = t.Time
+	}
+	if standup.Days == "" {
+		standup.Days = t.Days
+	}
+	if standup.ScheduleType == "" {
+		standup.ScheduleType = t.ScheduleType
+		if standup.IntervalWeeks == 0 {
+			standup.IntervalWeeks = t.IntervalWeeks
+		}
+		if standup.MonthWeek == 0 {
+			standup.MonthWeek = t.MonthWeek
+		}
+		if standup.CronExpr == "" {
+			standup.CronExpr = t.CronExpr
+		}
+	}
+	if standup.ReportMode == "" {
+		standup.ReportMode = t.ReportMode
+		if standup.DigestTime == "" {
+			standup.DigestTime = t.DigestTime
+		}
+	}
+}
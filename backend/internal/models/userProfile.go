@@ -9,5 +9,7 @@ type UserProfile struct {
     Avatar       string    `json:"avatar"`
 	Timezone     string `default:"UTC" json:"timezone"`
 	DiscordToken string	`json:"-"`
+	// ChatMode asks standup questions as plain DMs answered by replying, instead of forms.
+	ChatMode     bool      `json:"chat_mode"`
 	Standups     []Standup `gorm:"many2many:standup_participants;" json:"standups"`
 }
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSpec is a parsed five-field cron expression. A standup prompts once per day, so the
+// minute and hour must be single values; the day fields accept the usual lists, ranges,
+// steps and names.
+type cronSpec struct {
+	Minute, Hour int
+	dom          [32]bool
+	month        [13]bool
+	dow          [7]bool
+	domAny       bool
+	dowAny       bool
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronDayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.New("cron expressions need 5 fields: minute hour day-of-month month day-of-week")
+	}
+
+	spec := &cronSpec{}
+	var err error
+	if spec.Minute, err = strconv.Atoi(fields[0]); err != nil || spec.Minute < 0 || spec.Minute > 59 {
+		return nil, errors.New("cron minute must be a single value between 0 and 59")
+	}
+	if spec.Hour, err = strconv.Atoi(fields[1]); err != nil || spec.Hour < 0 || spec.Hour > 23 {
+		return nil, errors.New("cron hour must be a single value between 0 and 23")
+	}
+
+	if err := parseCronField(fields[2], 1, 31, nil, spec.dom[:]); err != nil {
+		return nil, fmt.Errorf("cron day-of-month: %w", err)
+	}
+	if err := parseCronField(fields[3], 1, 12, cronMonthNames, spec.month[:]); err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+
+	// Day-of-week accepts 7 as a second spelling of Sunday.
+	var dow [8]bool
+	if err := parseCronField(fields[4], 0, 7, cronDayNames, dow[:]); err != nil {
+		return nil, fmt.Errorf("cron day-of-week: %w", err)
+	}
+	copy(spec.dow[:], dow[:7])
+	spec.dow[0] = spec.dow[0] || dow[7]
+
+	spec.domAny = isCronDayUnrestricted(fields[2])
+	spec.dowAny = isCronDayUnrestricted(fields[4])
+	return spec, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// isCronDayUnrestricted reports whether a day field leaves the choice to the other one.
+// Like cron itself, any field starting with "*" counts, steps such as "*/2" included.
+func isCronDayUnrestricted(field string) bool {
+	return strings.HasPrefix(field, "*") || field == "?"
+}
+
+func parseCronField(field string, min, max int, names map[string]int, out []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if !isCronWildcard(rangePart) {
+			loStr, hiStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = cronValue(loStr, names); err != nil {
+				return err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = cronValue(hiStr, names); err != nil {
+					return err
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			out[v] = true
+		}
+	}
+	return nil
+}
+
+func cronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return n, nil
+}
+
+// matchesDay follows cron's rule that when both day fields are restricted, a day
+// matching either of them counts.
+func (c *cronSpec) matchesDay(day time.Time) bool {
+	if !c.month[int(day.Month())] {
+		return false
+	}
+	domMatch := c.dom[day.Day()]
+	dowMatch := c.dow[int(day.Weekday())]
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (c *cronSpec) clock() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour, c.Minute)
+}
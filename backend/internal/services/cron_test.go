@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+func day(date string) time.Time {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+		clock   string
+	}{
+		{expr: "30 9 * * 1-5", clock: "09:30"},
+		{expr: "0 17 * * FRI", clock: "17:00"},
+		{expr: "5 0 1,15 * *", clock: "00:05"},
+		{expr: "0 9 */2 JAN-MAR ?", clock: "09:00"},
+		{expr: "0 9 * * 7", clock: "09:00"},
+		{expr: "0 9 * *", wantErr: true},
+		{expr: "0 9 * * * *", wantErr: true},
+		{expr: "*/15 9 * * *", wantErr: true},
+		{expr: "0 9-17 * * *", wantErr: true},
+		{expr: "60 9 * * *", wantErr: true},
+		{expr: "0 24 * * *", wantErr: true},
+		{expr: "0 9 0 * *", wantErr: true},
+		{expr: "0 9 32 * *", wantErr: true},
+		{expr: "0 9 * 13 *", wantErr: true},
+		{expr: "0 9 * * 8", wantErr: true},
+		{expr: "0 9 * * 5-1", wantErr: true},
+		{expr: "0 9 * * */0", wantErr: true},
+		{expr: "0 9 * * FUNDAY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			spec, err := parseCron(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCron(%q) = nil error, want one", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCron(%q) = %v", tt.expr, err)
+			}
+			if got := spec.clock(); got != tt.clock {
+				t.Errorf("clock() = %q, want %q", got, tt.clock)
+			}
+		})
+	}
+}
+
+func TestCronMatchesDay(t *testing.T) {
+	// 2026-03-02 is a Monday.
+	tests := []struct {
+		name string
+		expr string
+		date string
+		want bool
+	}{
+		{"weekdays on a Monday", "0 9 * * 1-5", "2026-03-02", true},
+		{"weekdays on a Saturday", "0 9 * * 1-5", "2026-03-07", false},
+		{"day names", "0 9 * * MON,WED", "2026-03-04", true},
+		{"7 is Sunday", "0 9 * * 7", "2026-03-08", true},
+		{"0 is Sunday", "0 9 * * 0", "2026-03-08", true},
+		{"day of month", "0 9 15 * *", "2026-03-15", true},
+		{"other day of month", "0 9 15 * *", "2026-03-16", false},
+		{"month restricts", "0 9 * JAN *", "2026-03-02", false},
+		{"month range", "0 9 * FEB-APR *", "2026-03-02", true},
+		{"day of month step", "0 9 1-31/2 * *", "2026-03-03", true},
+		{"day of month step misses", "0 9 1-31/2 * *", "2026-03-04", false},
+		{"both restricted, only dom matches", "0 9 15 * MON", "2026-03-15", true},
+		{"both restricted, only dow matches", "0 9 15 * MON", "2026-03-02", true},
+		{"both restricted, neither matches", "0 9 15 * MON", "2026-03-03", false},
+		{"starred dom step with dow needs both", "0 9 */2 * MON", "2026-03-02", false},
+		{"starred dom step with dow, both match", "0 9 */2 * MON", "2026-03-09", true},
+		{"starred dow step with dom needs both", "0 9 15 * */2", "2026-03-15", true},
+		{"starred dow step with dom misses", "0 9 15 * */2", "2026-03-16", false},
+		{"question mark is unrestricted", "0 9 ? * MON", "2026-03-02", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q) = %v", tt.expr, err)
+			}
+			if got := spec.matchesDay(day(tt.date)); got != tt.want {
+				t.Errorf("matchesDay(%s) with %q = %v, want %v", tt.date, tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCadenceMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		standup models.Standup
+		date    string
+		want    bool
+	}{
+		{"weekly default days", models.Standup{}, "2026-03-02", true},
+		{"weekly default skips weekends", models.Standup{}, "2026-03-07", false},
+		{"weekly chosen day", models.Standup{Days: "Friday"}, "2026-03-06", true},
+		{"weekly other day", models.Standup{Days: "Friday"}, "2026-03-05", false},
+
+		{"fortnightly anchor week", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 2, AnchorDate: "2026-03-04", Days: "Monday"}, "2026-03-02", true},
+		{"fortnightly off week", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 2, AnchorDate: "2026-03-04", Days: "Monday"}, "2026-03-09", false},
+		{"fortnightly next on week", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 2, AnchorDate: "2026-03-04", Days: "Monday"}, "2026-03-16", true},
+		{"fortnightly before the anchor", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 2, AnchorDate: "2026-03-04", Days: "Monday"}, "2026-02-16", true},
+		{"every third week", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 3, AnchorDate: "2026-03-02", Days: "Monday"}, "2026-03-23", true},
+		{"interval wrong weekday", models.Standup{ScheduleType: models.ScheduleInterval,
+			IntervalWeeks: 2, AnchorDate: "2026-03-02", Days: "Monday"}, "2026-03-03", false},
+
+		{"first Monday", models.Standup{ScheduleType: models.ScheduleMonthly,
+			MonthWeek: 1, Days: "Monday"}, "2026-03-02", true},
+		{"second Monday is not the first", models.Standup{ScheduleType: models.ScheduleMonthly,
+			MonthWeek: 1, Days: "Monday"}, "2026-03-09", false},
+		{"third Friday", models.Standup{ScheduleType: models.ScheduleMonthly,
+			MonthWeek: 3, Days: "Friday"}, "2026-03-20", true},
+		{"last Tuesday", models.Standup{ScheduleType: models.ScheduleMonthly,
+			MonthWeek: -1, Days: "Tuesday"}, "2026-03-31", true},
+		{"second to last Tuesday", models.Standup{ScheduleType: models.ScheduleMonthly,
+			MonthWeek: -1, Days: "Tuesday"}, "2026-03-24", false},
+
+		{"cron", models.Standup{ScheduleType: models.ScheduleCron, CronExpr: "0 9 1 * *"}, "2026-03-01", true},
+		{"cron off day", models.Standup{ScheduleType: models.ScheduleCron, CronExpr: "0 9 1 * *"}, "2026-03-02", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runsOn, err := cadenceMatcher(tt.standup)
+			if err != nil {
+				t.Fatalf("cadenceMatcher() = %v", err)
+			}
+			if got := runsOn(day(tt.date)); got != tt.want {
+				t.Errorf("runs on %s = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCadenceMatcherRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		standup models.Standup
+	}{
+		{"interval without anchor", models.Standup{ScheduleType: models.ScheduleInterval, IntervalWeeks: 2}},
+		{"zero week interval", models.Standup{ScheduleType: models.ScheduleInterval, AnchorDate: "2026-03-02"}},
+		{"fifth week of the month", models.Standup{ScheduleType: models.ScheduleMonthly, MonthWeek: 5}},
+		{"bad cron", models.Standup{ScheduleType: models.ScheduleCron, CronExpr: "every day"}},
+		{"unknown type", models.Standup{ScheduleType: "hourly"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cadenceMatcher(tt.standup); err == nil {
+				t.Error("cadenceMatcher() = nil error, want one")
+			}
+		})
+	}
+}
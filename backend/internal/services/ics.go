@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+const (
+	maxICSHolidays     = 5000
+	maxICSEventDays    = 366
+	icsRecurrenceYears = 3
+)
+
+// parseICS extracts the days covered by every VEVENT in an iCalendar file. It only needs
+// the bytes that were uploaded; nothing is fetched. Yearly recurrence rules (the norm for
+// public holiday feeds) are expanded a few years ahead, other rules only keep their first
+// occurrence.
+func parseICS(data []byte) ([]models.Holiday, error) {
+	lines := unfoldICSLines(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("file is not an iCalendar (.ics) calendar")
+	}
+
+	seen := make(map[string]bool)
+	var holidays []models.Holiday
+	var event map[string]string
+
+	for _, line := range lines {
+		name, value, ok := parseICSLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = make(map[string]string)
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				continue
+			}
+			for _, h := range expandICSEvent(event) {
+				if seen[h.Date] {
+					continue
+				}
+				seen[h.Date] = true
+				holidays = append(holidays, h)
+				if len(holidays) >= maxICSHolidays {
+					return holidays, nil
+				}
+			}
+			event = nil
+		case event != nil:
+			if _, exists := event[name]; !exists {
+				event[name] = value
+			}
+		}
+	}
+
+	if len(holidays) == 0 {
+		return nil, errors.New("no events with dates were found in the calendar")
+	}
+	return holidays, nil
+}
+
+func unfoldICSLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICSLine splits "NAME;PARAM=...:VALUE" into its upper-cased name and value.
+// Parameters such as TZID are dropped: only the calendar date matters here.
+func parseICSLine(line string) (string, string, bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", false
+	}
+	name, _, _ := strings.Cut(head, ";")
+	return strings.ToUpper(name), value, true
+}
+
+// parseICSDate reads a DATE or DATE-TIME value. The second result reports whether the
+// value carried a time of day other than midnight.
+func parseICSDate(value string) (time.Time, bool, error) {
+	if len(value) < 8 {
+		return time.Time{}, false, errors.New("invalid date")
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	hasTime := len(value) >= 15 && value[8] == 'T' && value[9:15] != "000000"
+	return day, hasTime, nil
+}
+
+func expandICSEvent(event map[string]string) []models.Holiday {
+	start, ok := event["DTSTART"]
+	if !ok {
+		return nil
+	}
+	startDay, _, err := parseICSDate(start)
+	if err != nil {
+		return nil
+	}
+
+	// DTEND is exclusive, except when a timed event ends partway through its last day.
+	days := 1
+	if end, ok := event["DTEND"]; ok {
+		if endDay, hasTime, err := parseICSDate(end); err == nil && endDay.After(startDay) {
+			days = int(endDay.Sub(startDay).Hours() / 24)
+			if hasTime {
+				days++
+			}
+		}
+	}
+	if days > maxICSEventDays {
+		days = maxICSEventDays
+	}
+
+	name := unescapeICSText(event["SUMMARY"])
+	if name == "" {
+		name = "Holiday"
+	}
+
+	occurrences := []time.Time{startDay}
+	if rule, ok := event["RRULE"]; ok {
+		occurrences = expandYearlyRule(startDay, rule)
+	}
+
+	var holidays []models.Holiday
+	for _, occurrence := range occurrences {
+		for i := 0; i < days; i++ {
+			holidays = append(holidays, models.Holiday{
+				Date: occurrence.AddDate(0, 0, i).Format("2006-01-02"),
+				Name: name,
+			})
+		}
+	}
+	return holidays
+}
+
+func expandYearlyRule(start time.Time, rule string) []time.Time {
+	params := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		params[strings.ToUpper(key)] = val
+	}
+
+	if params["FREQ"] != "YEARLY" || params["BYDAY"] != "" || params["BYMONTHDAY"] != "" {
+		return []time.Time{start}
+	}
+
+	interval, err := strconv.Atoi(params["INTERVAL"])
+	if err != nil || interval < 1 {
+		interval = 1
+	}
+	count, _ := strconv.Atoi(params["COUNT"])
+
+	until := time.Date(time.Now().Year()+icsRecurrenceYears, 12, 31, 0, 0, 0, 0, time.UTC)
+	if value, ok := params["UNTIL"]; ok {
+		if day, _, err := parseICSDate(value); err == nil && day.Before(until) {
+			until = day
+		}
+	}
+
+	// Long-running feeds often start decades ago; only recent years are worth storing.
+	since := time.Now().UTC().AddDate(-1, 0, 0)
+
+	occurrences := []time.Time{start}
+	for n := 1; count == 0 || n < count; n++ {
+		occurrence := start.AddDate(n*interval, 0, 0)
+		if occurrence.After(until) {
+			break
+		}
+		if occurrence.Before(since) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(value))
+}
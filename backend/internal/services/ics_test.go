@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func icsCalendar(lines ...string) []byte {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	all = append(all, "END:VCALENDAR")
+	return []byte(strings.Join(all, "\r\n") + "\r\n")
+}
+
+func TestParseICS(t *testing.T) {
+	year := time.Now().Year()
+
+	tests := []struct {
+		name  string
+		data  []byte
+		dates []string
+		names []string
+	}{
+		{
+			name: "all-day VALUE=DATE event",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261225", "DTEND;VALUE=DATE:20261226",
+				"SUMMARY:Christmas Day", "END:VEVENT"),
+			dates: []string{"2026-12-25"},
+			names: []string{"Christmas Day"},
+		},
+		{
+			name: "multi-day all-day event ends before DTEND",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20260803", "DTEND;VALUE=DATE:20260806",
+				"SUMMARY:Offsite", "END:VEVENT"),
+			dates: []string{"2026-08-03", "2026-08-04", "2026-08-05"},
+		},
+		{
+			name: "timed event covers its last day",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;TZID=Europe/Berlin:20260803T090000",
+				"DTEND;TZID=Europe/Berlin:20260804T120000", "SUMMARY:Workshop", "END:VEVENT"),
+			dates: []string{"2026-08-03", "2026-08-04"},
+		},
+		{
+			name:  "event without DTEND is one day",
+			data:  icsCalendar("BEGIN:VEVENT", "DTSTART:20260501", "SUMMARY:Labour Day", "END:VEVENT"),
+			dates: []string{"2026-05-01"},
+		},
+		{
+			name: "folded lines are joined",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261003", "SUMMARY:Day of German",
+				"  Unity", "END:VEVENT"),
+			dates: []string{"2026-10-03"},
+			names: []string{"Day of German Unity"},
+		},
+		{
+			name: "folded with a tab and escaped text",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20260101", "SUMMARY:New Year\\, ",
+				"\tobserved", "END:VEVENT"),
+			dates: []string{"2026-01-01"},
+			names: []string{"New Year, observed"},
+		},
+		{
+			name:  "folded property name",
+			data:  icsCalendar("BEGIN:VEVENT", "DTST", " ART;VALUE=DATE:20260704", "END:VEVENT"),
+			dates: []string{"2026-07-04"},
+			names: []string{"Holiday"},
+		},
+		{
+			name: "LF line endings",
+			data: []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20260317\n" +
+				"SUMMARY:St Patrick's Day\nEND:VEVENT\nEND:VCALENDAR\n"),
+			dates: []string{"2026-03-17"},
+		},
+		{
+			name: "duplicate dates are kept once",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261226", "SUMMARY:Boxing Day", "END:VEVENT",
+				"BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261226", "SUMMARY:St Stephen's Day", "END:VEVENT"),
+			dates: []string{"2026-12-26"},
+			names: []string{"Boxing Day"},
+		},
+		{
+			name: "yearly rule with a count",
+			data: icsCalendar("BEGIN:VEVENT", fmt.Sprintf("DTSTART;VALUE=DATE:%d0101", year),
+				"RRULE:FREQ=YEARLY;COUNT=3", "SUMMARY:New Year", "END:VEVENT"),
+			dates: []string{
+				fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-01-01", year+1), fmt.Sprintf("%d-01-01", year+2),
+			},
+		},
+		{
+			name: "yearly rule until a date",
+			data: icsCalendar("BEGIN:VEVENT", fmt.Sprintf("DTSTART;VALUE=DATE:%d1225", year),
+				fmt.Sprintf("RRULE:FREQ=YEARLY;UNTIL=%d1225", year+1), "SUMMARY:Christmas", "END:VEVENT"),
+			dates: []string{fmt.Sprintf("%d-12-25", year), fmt.Sprintf("%d-12-25", year+1)},
+		},
+		{
+			name: "yearly rule every other year",
+			data: icsCalendar("BEGIN:VEVENT", fmt.Sprintf("DTSTART;VALUE=DATE:%d0601", year),
+				"RRULE:FREQ=YEARLY;INTERVAL=2;COUNT=2", "END:VEVENT"),
+			dates: []string{fmt.Sprintf("%d-06-01", year), fmt.Sprintf("%d-06-01", year+2)},
+		},
+		{
+			name: "yearly rule stops a few years ahead",
+			data: icsCalendar("BEGIN:VEVENT", fmt.Sprintf("DTSTART;VALUE=DATE:%d0501", year),
+				"RRULE:FREQ=YEARLY", "END:VEVENT"),
+			dates: []string{
+				fmt.Sprintf("%d-05-01", year), fmt.Sprintf("%d-05-01", year+1),
+				fmt.Sprintf("%d-05-01", year+2), fmt.Sprintf("%d-05-01", year+3),
+			},
+		},
+		{
+			name: "yearly rule skips long-past years",
+			data: icsCalendar("BEGIN:VEVENT", fmt.Sprintf("DTSTART;VALUE=DATE:%d0704", year-10),
+				fmt.Sprintf("RRULE:FREQ=YEARLY;UNTIL=%d1231", year-2), "END:VEVENT"),
+			dates: []string{fmt.Sprintf("%d-07-04", year-10)},
+		},
+		{
+			name: "yearly rule by weekday keeps only the first occurrence",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261126",
+				"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "END:VEVENT"),
+			dates: []string{"2026-11-26"},
+		},
+		{
+			name: "other frequencies keep only the first occurrence",
+			data: icsCalendar("BEGIN:VEVENT", "DTSTART;VALUE=DATE:20260105",
+				"RRULE:FREQ=WEEKLY;COUNT=4", "END:VEVENT"),
+			dates: []string{"2026-01-05"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays, err := parseICS(tt.data)
+			if err != nil {
+				t.Fatalf("parseICS() = %v", err)
+			}
+
+			var dates []string
+			for _, h := range holidays {
+				dates = append(dates, h.Date)
+			}
+			if strings.Join(dates, ",") != strings.Join(tt.dates, ",") {
+				t.Errorf("dates = %v, want %v", dates, tt.dates)
+			}
+			for i, name := range tt.names {
+				if i < len(holidays) && holidays[i].Name != name {
+					t.Errorf("holiday %d name = %q, want %q", i, holidays[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"not a calendar", "hello,world\n1,2\n"},
+		{"no events", "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nEND:VCALENDAR\r\n"},
+		{"events without dates", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Nothing\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{"bad date", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:2026-13\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseICS([]byte(tt.data)); err == nil {
+				t.Error("parseICS() = nil error, want one")
+			}
+		})
+	}
+}
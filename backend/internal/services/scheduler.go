@@ -0,0 +1,154 @@
+package services
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+const (
+	entryPrompt   = "prompt"
+	entryReminder = "reminder"
+	entryDeadline = "deadline"
+	entryDigest   = "digest"
+	entryResume   = "resume"
+)
+
+type scheduleEntry struct {
+	Kind      string
+	StandupID uint
+	UserID    string
+	FireAt    time.Time
+	index     int
+}
+
+type fireQueue []*scheduleEntry
+
+func (q fireQueue) Len() int           { return len(q) }
+func (q fireQueue) Less(i, j int) bool { return q[i].FireAt.Before(q[j].FireAt) }
+
+func (q fireQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *fireQueue) Push(x any) {
+	entry := x.(*scheduleEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *fireQueue) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*q = old[:n-1]
+	return entry
+}
+
+// Scheduler keeps every pending event (a participant's prompt, their next reminder, ...)
+// ordered by its fire instant in UTC, so a tick only touches the entries that are due.
+type Scheduler struct {
+	mu      sync.Mutex
+	queue   fireQueue
+	entries map[string]*scheduleEntry
+	loaded  bool
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{entries: make(map[string]*scheduleEntry)}
+}
+
+// Load replaces the queue contents with a freshly computed set of entries.
+func (sc *Scheduler) Load(entries []scheduleEntry) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.queue = make(fireQueue, 0, len(entries))
+	sc.entries = make(map[string]*scheduleEntry, len(entries))
+	for _, e := range entries {
+		if e.FireAt.IsZero() {
+			continue
+		}
+		entry := &scheduleEntry{Kind: e.Kind, StandupID: e.StandupID, UserID: e.UserID, FireAt: e.FireAt,
+			index: len(sc.queue)}
+		sc.queue = append(sc.queue, entry)
+		sc.entries[entryKey(e.Kind, e.StandupID, e.UserID)] = entry
+	}
+	heap.Init(&sc.queue)
+	sc.loaded = true
+}
+
+// Reset drops every entry, e.g. when this replica stops being the scheduler leader.
+func (sc *Scheduler) Reset() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.queue = nil
+	sc.entries = make(map[string]*scheduleEntry)
+	sc.loaded = false
+}
+
+func (sc *Scheduler) Loaded() bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.loaded
+}
+
+func (sc *Scheduler) Len() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return len(sc.queue)
+}
+
+// Set inserts or moves an entry. A zero fireAt removes it.
+func (sc *Scheduler) Set(kind string, standupID uint, userID string, fireAt time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	key := entryKey(kind, standupID, userID)
+	entry, exists := sc.entries[key]
+
+	if fireAt.IsZero() {
+		if exists {
+			heap.Remove(&sc.queue, entry.index)
+			delete(sc.entries, key)
+		}
+		return
+	}
+
+	if exists {
+		entry.FireAt = fireAt
+		heap.Fix(&sc.queue, entry.index)
+		return
+	}
+
+	entry = &scheduleEntry{Kind: kind, StandupID: standupID, UserID: userID, FireAt: fireAt}
+	heap.Push(&sc.queue, entry)
+	sc.entries[key] = entry
+}
+
+func (sc *Scheduler) Remove(kind string, standupID uint, userID string) {
+	sc.Set(kind, standupID, userID, time.Time{})
+}
+
+// PopDue removes and returns every entry whose fire instant is not after now.
+func (sc *Scheduler) PopDue(now time.Time) []scheduleEntry {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	var due []scheduleEntry
+	for len(sc.queue) > 0 && !sc.queue[0].FireAt.After(now) {
+		entry := heap.Pop(&sc.queue).(*scheduleEntry)
+		delete(sc.entries, entryKey(entry.Kind, entry.StandupID, entry.UserID))
+		due = append(due, *entry)
+	}
+	return due
+}
+
+func entryKey(kind string, standupID uint, userID string) string {
+	return kind + ":" + scheduleKey(standupID, userID)
+}
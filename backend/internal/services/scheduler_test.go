@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// BenchmarkSchedulerTick measures one minute's worth of work: pop whatever is due and
+// push it back for its next occurrence. Exactly one standup is due per tick, so the
+// cost should stay flat as the total number of standups in the queue grows.
+func BenchmarkSchedulerTick(b *testing.B) {
+	const participantsPerStandup = 8
+
+	for _, standups := range []int{100, 1_000, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("standups=%d", standups), func(b *testing.B) {
+			start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+			// One standup per minute, so the queue spans `standups` minutes.
+			entries := make([]scheduleEntry, 0, standups*participantsPerStandup)
+			for st := 0; st < standups; st++ {
+				fireAt := start.Add(time.Duration(st) * time.Minute)
+				for p := 0; p < participantsPerStandup; p++ {
+					entries = append(entries, scheduleEntry{
+						Kind:      entryPrompt,
+						StandupID: uint(st + 1),
+						UserID:    fmt.Sprintf("user-%d", p),
+						FireAt:    fireAt,
+					})
+				}
+			}
+
+			sc := NewScheduler()
+			sc.Load(entries)
+			now := start
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, entry := range sc.PopDue(now) {
+					sc.Set(entry.Kind, entry.StandupID, entry.UserID, entry.FireAt.Add(time.Duration(standups)*time.Minute))
+				}
+				now = now.Add(time.Minute)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// noBlockerAnswers are the ways people say there is nothing in their way.
+var noBlockerAnswers = map[string]bool{
+	"none": true, "no": true, "nope": true, "nothing": true, "n/a": true, "na": true,
+	"no blockers": true, "no blocker": true, "none so far": true, "-": true,
+}
+
+// IsBlockerAnswer reports whether an answer to the blocker question describes an actual
+// blocker rather than being empty or some form of "none".
+func IsBlockerAnswer(answer string) bool {
+	normalized := strings.ToLower(strings.Trim(strings.TrimSpace(answer), ".!"))
+	if normalized == "" || answer == models.SkippedAnswer || answer == models.OutOfOfficeAnswer {
+		return false
+	}
+	return !noBlockerAnswers[normalized]
+}
+
+// TrackBlocker keeps the blocker raised by a report in line with its answer to the
+// blocker question, opening one (and DMing the manager) when a blocker is first reported
+// and dropping it if an edit takes it back. It returns the report's blocker, if any.
+func (s *StandupService) TrackBlocker(standup models.Standup, history models.StandupHistory) *models.Blocker {
+	var existing models.Blocker
+	found := s.DB.Where("history_id = ?", history.ID).First(&existing).Error == nil
+
+	answer := ""
+	if q, ok := standup.BlockerQuestion(); ok {
+		answer, _ = history.AnswerToQuestion(standup, q)
+	}
+
+	if !IsBlockerAnswer(answer) {
+		if found && existing.Status == models.BlockerOpen {
+			s.DB.Delete(&existing)
+		}
+		return nil
+	}
+
+	answer = strings.TrimSpace(answer)
+	if found {
+		if existing.Text != answer && existing.Status == models.BlockerOpen {
+			existing.Text = answer
+			s.DB.Save(&existing)
+		}
+		return &existing
+	}
+
+	blocker := models.Blocker{
+		StandupID: standup.ID,
+		HistoryID: history.ID,
+		UserID:    history.UserID,
+		Text:      answer,
+		Status:    models.BlockerOpen,
+	}
+	if err := s.DB.Create(&blocker).Error; err != nil {
+		log.Printf("Error saving blocker for standup %d: %v", standup.ID, err)
+		return nil
+	}
+
+	s.notifyManagerOfBlocker(standup, blocker)
+	return &blocker
+}
+
+func (s *StandupService) notifyManagerOfBlocker(standup models.Standup, blocker models.Blocker) {
+	for _, managerID := range s.managerIDs(standup) {
+		if managerID == blocker.UserID {
+			continue
+		}
+
+		dmChannel, err := s.Session.UserChannelCreate(managerID)
+		if err != nil {
+			continue
+		}
+
+		if _, err := s.Session.ChannelMessageSendComplex(dmChannel.ID, &discordgo.MessageSend{
+			Content: fmt.Sprintf("🚧 <@%s> reported a blocker in **%s**:\n> %s", blocker.UserID, standup.Name,
+				truncateText(blocker.Text, maxEmbedFieldValue)),
+			Components: BlockerComponents(blocker),
+		}); err != nil {
+			log.Printf("Warning: Failed to DM manager about blocker %d: %v", blocker.ID, err)
+		}
+	}
+}
+
+// BlockerComponents is the "Resolved" button shown wherever a blocker is reported, or a
+// disabled stand-in once it has been resolved.
+func BlockerComponents(blocker models.Blocker) []discordgo.MessageComponent {
+	button := discordgo.Button{
+		Label:    "✅ Resolved",
+		Style:    discordgo.SuccessButton,
+		CustomID: fmt.Sprintf("resolve_blocker_%d", blocker.ID),
+	}
+	if blocker.Status == models.BlockerResolved {
+		button.Label = "✅ Blocker resolved"
+		button.Style = discordgo.SecondaryButton
+		button.Disabled = true
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{button}},
+	}
+}
+
+// reportBlockerComponents returns the buttons for a posted report: the blocker's, or none.
+func (s *StandupService) reportBlockerComponents(historyID uint) []discordgo.MessageComponent {
+	var blocker models.Blocker
+	if err := s.DB.Where("history_id = ?", historyID).First(&blocker).Error; err != nil {
+		return []discordgo.MessageComponent{}
+	}
+	return BlockerComponents(blocker)
+}
+
+// ResolveBlocker closes a blocker on behalf of its owner or one of the standup's managers, and
+// updates the posted report so its button shows the new status.
+func (s *StandupService) ResolveBlocker(blockerID uint, userID string) (*models.Blocker, error) {
+	var blocker models.Blocker
+	if err := s.DB.First(&blocker, blockerID).Error; err != nil {
+		return nil, errors.New("blocker not found")
+	}
+
+	var standup models.Standup
+	s.DB.First(&standup, blocker.StandupID)
+	if blocker.UserID != userID && !s.Authorize(standup, userID, false, models.AccessManage) {
+		return nil, errors.New("only the person who reported the blocker or the standup's managers can resolve it")
+	}
+	if blocker.Status == models.BlockerResolved {
+		return &blocker, nil
+	}
+
+	now := time.Now()
+	blocker.Status = models.BlockerResolved
+	blocker.ResolvedBy = userID
+	blocker.ResolvedAt = &now
+	if err := s.DB.Save(&blocker).Error; err != nil {
+		return nil, err
+	}
+
+	var history models.StandupHistory
+	if err := s.DB.First(&history, blocker.HistoryID).Error; err == nil && history.MessageID != "" {
+		components := BlockerComponents(blocker)
+		if _, err := s.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         history.MessageID,
+			Channel:    history.ChannelID,
+			Components: &components,
+		}); err != nil {
+			log.Printf("Warning: Failed to update report message %s: %v", history.MessageID, err)
+		}
+	}
+
+	if userID != blocker.UserID {
+		if dmChannel, err := s.Session.UserChannelCreate(blocker.UserID); err == nil {
+			s.Session.ChannelMessageSend(dmChannel.ID, fmt.Sprintf(
+				"✅ <@%s> marked your **%s** blocker as resolved:\n> %s", userID, standup.Name,
+				truncateText(blocker.Text, maxEmbedFieldValue)))
+		}
+	}
+	return &blocker, nil
+}
+
+// GetBlockers lists the blockers of the given standups with the given status, or every
+// status when it is empty, oldest first.
+func (s *StandupService) GetBlockers(standupIDs []uint, status string) ([]models.Blocker, error) {
+	var blockers []models.Blocker
+	if len(standupIDs) == 0 {
+		return blockers, nil
+	}
+
+	query := s.DB.Where("standup_id IN ?", standupIDs)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at asc").Find(&blockers).Error
+	return blockers, err
+}
+
+// FormatBlockerAge renders how long a blocker has been open, e.g. "3d", "5h" or "12m".
+func FormatBlockerAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"log"
+	"time"
+)
+
+// claimTimeout is how long a claim may stay unfinished before another attempt takes it
+// over, in case the process holding it died half way through posting.
+const claimTimeout = 2 * time.Minute
+
+// claimDate inserts claim, a row keyed by a unique (standup, date) index, before the
+// message it records is posted. Only one insert can succeed, which keeps a restart or a
+// second replica from posting the same summary, digest or thread twice. Whoever wins
+// must releaseClaim if posting fails, or the date stays claimed with nothing to show.
+func (s *StandupService) claimDate(claim interface{}) bool {
+	return s.DB.Create(claim).Error == nil
+}
+
+// takeOverStaleClaim claims the date again when the existing row for it is older than
+// claimTimeout and the SQL condition unfinished still holds for it. Only one caller can
+// delete that row, so the date keeps a single owner.
+func (s *StandupService) takeOverStaleClaim(claim interface{}, standupID uint, date, unfinished string) bool {
+	result := s.DB.Unscoped().
+		Where("standup_id = ? AND date = ? AND created_at < ?", standupID, date, time.Now().Add(-claimTimeout)).
+		Where(unfinished).Delete(claim)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false
+	}
+	log.Printf("Taking over a stale %T claim for standup %d on %s", claim, standupID, date)
+	return s.claimDate(claim)
+}
+
+// releaseClaim gives up a claim whose message never made it out, so the date can be
+// claimed again.
+func (s *StandupService) releaseClaim(claim interface{}) {
+	if err := s.DB.Unscoped().Delete(claim).Error; err != nil {
+		log.Printf("Error releasing claim %T: %v", claim, err)
+	}
+}
+
+// retryStandupEvent releases the claim of a standup-wide event that failed to post and
+// queues it again at its original due time. The next tick retries it, and the catch-up
+// window stops the retries once it is too late to be worth posting.
+func (s *StandupService) retryStandupEvent(kind string, standupID uint, claim interface{}, dueAt time.Time) {
+	s.releaseClaim(claim)
+	s.scheduler().Set(kind, standupID, "", dueAt)
+}
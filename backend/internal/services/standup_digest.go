@@ -0,0 +1,336 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxEmbedsPerMessage   = 10
+	maxDigestMessageChars = 5500
+	maxEmbedFieldName     = 256
+	maxEmbedFieldValue    = 1024
+)
+
+func (s *StandupService) fireDigest(standup models.Standup, dueAt, now time.Time) {
+	loc := s.standupLocation(standup)
+	s.scheduler().Set(entryDigest, standup.ID, "", nextOccurrence(standup, standup.DigestTime, loc, now,
+		s.holidayDates(standup.ID)))
+
+	if now.Sub(dueAt) > s.catchUpWindow() || standup.ReportChannelID == "" {
+		return
+	}
+
+	digest := models.StandupDigest{
+		StandupID: standup.ID,
+		Date:      dueAt.In(loc).Format("2006-01-02"),
+		ChannelID: standup.ReportChannelID,
+	}
+	if !s.claimDate(&digest) {
+		return
+	}
+
+	if err := s.publishDigest(standup, &digest); err != nil {
+		log.Printf("Error publishing digest for standup %d: %v", standup.ID, err)
+		if len(digest.MessageIDs) == 0 {
+			s.retryStandupEvent(entryDigest, standup.ID, &digest, dueAt)
+		}
+		// Otherwise the pages that made it out stay up, and the next late submission's
+		// UpdateDigest sends the rest.
+	}
+}
+
+// UpdateDigest folds a late submission, skip or retraction into the digest that has
+// already been posted for its day. Before the digest goes out there is nothing to do:
+// the answer waits in history.
+func (s *StandupService) UpdateDigest(standup models.Standup, history models.StandupHistory) {
+	var user models.UserProfile
+	s.DB.Where("user_id = ?", history.UserID).First(&user)
+
+	var digest models.StandupDigest
+	if err := s.DB.Where("standup_id = ? AND date = ?", standup.ID,
+		s.digestDate(standup, user, history.Date)).First(&digest).Error; err != nil {
+		return
+	}
+
+	if err := s.publishDigest(standup, &digest); err != nil {
+		log.Printf("Error updating digest for standup %d: %v", standup.ID, err)
+	}
+}
+
+// digestDate is the date of the digest that a participant's report for date belongs to.
+// Reports carry the participant's own local date while digests are dated on the
+// standup's clock, so the report is filed under the day its prompt fell on by the clock
+// that prompt followed. For an anchored standup that is the standup's timezone; otherwise
+// each prompt follows the participant's clock and the two dates agree.
+func (s *StandupService) digestDate(standup models.Standup, user models.UserProfile, date string) string {
+	prompt, ok := s.promptFor(standup, user, date)
+	if !ok {
+		return date
+	}
+	return prompt.Format("2006-01-02")
+}
+
+// promptFor returns the prompt a participant answered with a report for their local
+// date, in the location of the clock it followed.
+func (s *StandupService) promptFor(standup models.Standup, user models.UserProfile, date string) (time.Time, bool) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	personal := withOverride(standup, s.ParticipantOverride(standup.ID, user.UserID))
+	hour, minute, err := parseStandupTime(personal.Time)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	tzCache := make(map[string]*time.Location)
+	fireLoc := scheduleLocation(tzCache, standup, user)
+	userLoc := loadLocation(tzCache, user.Timezone, user.UserID)
+	for _, offset := range []int{0, -1, 1} {
+		prompt := time.Date(day.Year(), day.Month(), day.Day()+offset, hour, minute, 0, 0, fireLoc)
+		if prompt.In(userLoc).Format("2006-01-02") == date {
+			return prompt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// publishDigest renders every report for the digest's date and edits the existing digest
+// messages to match, sending extra pages when late submissions no longer fit.
+func (s *StandupService) publishDigest(standup models.Standup, digest *models.StandupDigest) error {
+	pages, err := s.buildDigestPages(standup, digest)
+	if err != nil {
+		return err
+	}
+
+	for i, page := range pages {
+		content := fmt.Sprintf("📰 **%s Daily Digest** for %s", standup.Name, digest.Date)
+		if len(pages) > 1 {
+			content += fmt.Sprintf(" *(page %d/%d)*", i+1, len(pages))
+		}
+		embeds := page
+
+		if i < len(digest.MessageIDs) {
+			_, err := s.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				ID:      digest.MessageIDs[i],
+				Channel: digest.ChannelID,
+				Content: &content,
+				Embeds:  &embeds,
+			})
+			if err != nil {
+				log.Printf("Warning: Failed to edit digest message %s: %v", digest.MessageIDs[i], err)
+			}
+			continue
+		}
+
+		msg, err := s.Session.ChannelMessageSendComplex(digest.ChannelID, &discordgo.MessageSend{
+			Content: content,
+			Embeds:  embeds,
+		})
+		if err != nil {
+			if len(digest.MessageIDs) > 0 {
+				s.DB.Model(digest).Update("message_ids", digest.MessageIDs)
+			}
+			return fmt.Errorf("failed to send digest page: %w", err)
+		}
+		digest.MessageIDs = append(digest.MessageIDs, msg.ID)
+	}
+
+	return s.DB.Model(digest).Update("message_ids", digest.MessageIDs).Error
+}
+
+func (s *StandupService) buildDigestPages(standup models.Standup,
+	digest *models.StandupDigest) ([][]*discordgo.MessageEmbed, error) {
+
+	day, err := time.Parse("2006-01-02", digest.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	// A report's own date can be a day either side of the digest's, see digestDate.
+	var candidates []models.StandupHistory
+	if err := s.DB.Where("standup_id = ? AND date >= ? AND date <= ?", standup.ID,
+		day.AddDate(0, 0, -1).Format("2006-01-02"), day.AddDate(0, 0, 1).Format("2006-01-02")).
+		Order("created_at asc").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	var userIDs []string
+	for _, h := range candidates {
+		userIDs = append(userIDs, h.UserID)
+	}
+
+	var profiles []models.UserProfile
+	s.DB.Where("user_id IN ?", userIDs).Find(&profiles)
+	profileMap := make(map[string]models.UserProfile, len(profiles))
+	for _, p := range profiles {
+		profileMap[p.UserID] = p
+	}
+
+	var histories []models.StandupHistory
+	var historyIDs []uint
+	for _, h := range candidates {
+		if s.digestDate(standup, profileMap[h.UserID], h.Date) == digest.Date {
+			histories = append(histories, h)
+			historyIDs = append(historyIDs, h.ID)
+		}
+	}
+
+	var embeds []*discordgo.MessageEmbed
+	var skipped, away []string
+
+	for _, h := range histories {
+		if len(h.Answers) == 1 && h.Answers[0] == models.SkippedAnswer {
+			skipped = append(skipped, fmt.Sprintf("<@%s>", h.UserID))
+			continue
+		}
+		if len(h.Answers) == 1 && h.Answers[0] == models.OutOfOfficeAnswer {
+			away = append(away, fmt.Sprintf("<@%s>", h.UserID))
+			continue
+		}
+
+		userName := profileMap[h.UserID].Username
+		if userName == "" {
+			userName = h.UserID
+		}
+
+		var fields []*discordgo.MessageEmbedField
+		for i, answer := range h.Answers {
+			question := h.AskedQuestion(standup, i)
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  truncateText(question.Text, maxEmbedFieldName),
+				Value: truncateText("👉 "+question.Spec().Display(answer), maxEmbedFieldValue),
+			})
+		}
+
+		embed := &discordgo.MessageEmbed{
+			Author: &discordgo.MessageEmbedAuthor{
+				Name:    fmt.Sprintf("%s's Standup", userName),
+				IconURL: profileMap[h.UserID].Avatar,
+			},
+			Color:     0x5865F2,
+			Fields:    fields,
+			Timestamp: h.CreatedAt.Format(time.RFC3339),
+		}
+		if !digest.CreatedAt.IsZero() && h.CreatedAt.After(digest.CreatedAt) {
+			embed.Footer = &discordgo.MessageEmbedFooter{Text: "Submitted after the digest was posted"}
+		} else if h.EditedAt != nil {
+			embed.Footer = &discordgo.MessageEmbedFooter{Text: "✏️ Edited"}
+		}
+		embeds = append(embeds, embed)
+	}
+
+	if pulse := s.teamPulseEmbed(standup, historyIDs); pulse != nil {
+		embeds = append(embeds, pulse)
+	}
+
+	if len(skipped) > 0 {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("⏭️ Skipped Today (%d)", len(skipped)),
+			Description: strings.Join(skipped, " "),
+			Color:       0x808080,
+		})
+	}
+
+	if len(away) > 0 {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("🌴 Out of Office (%d)", len(away)),
+			Description: strings.Join(away, " "),
+			Color:       0x808080,
+		})
+	}
+
+	if len(embeds) == 0 {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Description: "📭 No updates were submitted for this standup today.",
+			Color:       0x808080,
+		})
+	}
+
+	return paginateEmbeds(embeds), nil
+}
+
+// teamPulseEmbed sums up the typed answers in the digest's reports, e.g. the team's
+// average mood, or returns nil when nobody gave one.
+func (s *StandupService) teamPulseEmbed(standup models.Standup, historyIDs []uint) *discordgo.MessageEmbed {
+	if len(historyIDs) == 0 {
+		return nil
+	}
+	var answers []models.StandupAnswer
+	if err := s.DB.Where("history_id IN ?", historyIDs).Find(&answers).Error; err != nil {
+		return nil
+	}
+	stats := questionStats(standup, answers)
+
+	var fields []*discordgo.MessageEmbedField
+	for _, stat := range stats {
+		if stat.Responses == 0 {
+			continue
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  truncateText(stat.Question, maxEmbedFieldName),
+			Value: truncateText(FormatQuestionStat(stat), maxEmbedFieldValue),
+		})
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  "📈 Team Pulse",
+		Color:  0x57F287,
+		Fields: fields,
+	}
+}
+
+// paginateEmbeds splits embeds into messages that respect Discord's 10-embed and
+// 6000-character limits.
+func paginateEmbeds(embeds []*discordgo.MessageEmbed) [][]*discordgo.MessageEmbed {
+	var pages [][]*discordgo.MessageEmbed
+	var current []*discordgo.MessageEmbed
+	size := 0
+
+	for _, embed := range embeds {
+		n := embedSize(embed)
+		if len(current) > 0 && (len(current) == maxEmbedsPerMessage || size+n > maxDigestMessageChars) {
+			pages = append(pages, current)
+			current = nil
+			size = 0
+		}
+		current = append(current, embed)
+		size += n
+	}
+
+	if len(current) > 0 {
+		pages = append(pages, current)
+	}
+	return pages
+}
+
+func embedSize(embed *discordgo.MessageEmbed) int {
+	n := len(embed.Title) + len(embed.Description)
+	if embed.Author != nil {
+		n += len(embed.Author.Name)
+	}
+	if embed.Footer != nil {
+		n += len(embed.Footer.Text)
+	}
+	for _, f := range embed.Fields {
+		n += len(f.Name) + len(f.Value)
+	}
+	return n
+}
+
+func truncateText(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// holidaysSince bounds the dates loaded for scheduling; anything older can no longer
+// affect an upcoming occurrence in any timezone.
+func holidaysSince() string {
+	return time.Now().UTC().AddDate(0, 0, -2).Format("2006-01-02")
+}
+
+// holidayDates returns the upcoming days off from every calendar attached to the standup.
+func (s *StandupService) holidayDates(standupID uint) map[string]bool {
+	var dates []string
+	if err := s.DB.Table("holidays").
+		Joins("JOIN standup_holiday_calendars ON standup_holiday_calendars.holiday_calendar_id = holidays.calendar_id").
+		Where("standup_holiday_calendars.standup_id = ? AND holidays.date >= ?", standupID, holidaysSince()).
+		Pluck("holidays.date", &dates).Error; err != nil {
+		log.Printf("Error loading holidays for standup %d: %v", standupID, err)
+	}
+
+	holidays := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		holidays[date] = true
+	}
+	return holidays
+}
+
+// allHolidayDates is holidayDates for every standup at once, used by full rebuilds.
+func (s *StandupService) allHolidayDates() (map[uint]map[string]bool, error) {
+	var rows []struct {
+		StandupID uint
+		Date      string
+	}
+	if err := s.DB.Table("holidays").
+		Select("standup_holiday_calendars.standup_id, holidays.date").
+		Joins("JOIN standup_holiday_calendars ON standup_holiday_calendars.holiday_calendar_id = holidays.calendar_id").
+		Where("holidays.date >= ?", holidaysSince()).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	byStandup := make(map[uint]map[string]bool)
+	for _, row := range rows {
+		if byStandup[row.StandupID] == nil {
+			byStandup[row.StandupID] = make(map[string]bool)
+		}
+		byStandup[row.StandupID][row.Date] = true
+	}
+	return byStandup, nil
+}
+
+func (s *StandupService) GetHolidayCalendars(guildID string) ([]models.HolidayCalendar, error) {
+	var calendars []models.HolidayCalendar
+	err := s.DB.Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date asc")
+	}).Where("guild_id = ?", guildID).Order("name asc").Find(&calendars).Error
+	return calendars, err
+}
+
+// CreateHolidayCalendar stores a new guild calendar, or adds the dates to the guild's
+// existing calendar with the same name.
+func (s *StandupService) CreateHolidayCalendar(guildID, name string,
+	holidays []models.Holiday) (*models.HolidayCalendar, int, error) {
+
+	if guildID == "" {
+		return nil, 0, errors.New("guild ID cannot be empty")
+	}
+	if name == "" {
+		return nil, 0, errors.New("calendar name cannot be empty")
+	}
+	for _, h := range holidays {
+		if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+			return nil, 0, fmt.Errorf("invalid date %q, use YYYY-MM-DD", h.Date)
+		}
+	}
+
+	var calendar models.HolidayCalendar
+	if err := s.DB.Where(models.HolidayCalendar{GuildID: guildID, Name: name}).
+		FirstOrCreate(&calendar).Error; err != nil {
+		return nil, 0, err
+	}
+
+	added := 0
+	if len(holidays) > 0 {
+		for i := range holidays {
+			holidays[i].ID = 0
+			holidays[i].CalendarID = calendar.ID
+		}
+		result := s.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&holidays, 500)
+		if result.Error != nil {
+			return nil, 0, result.Error
+		}
+		added = int(result.RowsAffected)
+	}
+
+	s.invalidateCalendarStandups(calendar.ID)
+	return &calendar, added, nil
+}
+
+// MaxCalendarBytes caps the size of an .ics file accepted for import, from the dashboard
+// or from a Discord attachment.
+const MaxCalendarBytes = 1 << 20
+
+// ImportHolidayCalendar parses an uploaded .ics file and merges its dates into the named
+// guild calendar.
+func (s *StandupService) ImportHolidayCalendar(guildID, name string,
+	data []byte) (*models.HolidayCalendar, int, error) {
+
+	holidays, err := parseICS(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.CreateHolidayCalendar(guildID, name, holidays)
+}
+
+func (s *StandupService) RemoveHoliday(calendarID uint, date string) error {
+	result := s.DB.Where("calendar_id = ? AND date = ?", calendarID, date).Delete(&models.Holiday{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("that date is not in the calendar")
+	}
+
+	s.invalidateCalendarStandups(calendarID)
+	return nil
+}
+
+func (s *StandupService) DeleteHolidayCalendar(calendarID uint) error {
+	standupIDs := s.calendarStandupIDs(calendarID)
+
+	if err := s.DB.Exec("DELETE FROM standup_holiday_calendars WHERE holiday_calendar_id = ?",
+		calendarID).Error; err != nil {
+		return err
+	}
+	s.DB.Where("calendar_id = ?", calendarID).Delete(&models.Holiday{})
+	if err := s.DB.Unscoped().Delete(&models.HolidayCalendar{}, calendarID).Error; err != nil {
+		return err
+	}
+
+	for _, id := range standupIDs {
+		s.InvalidateStandup(id)
+	}
+	return nil
+}
+
+func (s *StandupService) AttachHolidayCalendar(standupID, calendarID uint) error {
+	standup, calendar, err := s.loadStandupAndCalendar(standupID, calendarID)
+	if err != nil {
+		return err
+	}
+	if err := s.DB.Model(standup).Association("HolidayCalendars").Append(calendar); err != nil {
+		return err
+	}
+
+	s.InvalidateStandup(standupID)
+	return nil
+}
+
+func (s *StandupService) DetachHolidayCalendar(standupID, calendarID uint) error {
+	standup, calendar, err := s.loadStandupAndCalendar(standupID, calendarID)
+	if err != nil {
+		return err
+	}
+	if err := s.DB.Model(standup).Association("HolidayCalendars").Delete(calendar); err != nil {
+		return err
+	}
+
+	s.InvalidateStandup(standupID)
+	return nil
+}
+
+func (s *StandupService) loadStandupAndCalendar(standupID,
+	calendarID uint) (*models.Standup, *models.HolidayCalendar, error) {
+
+	var standup models.Standup
+	if err := s.DB.First(&standup, standupID).Error; err != nil {
+		return nil, nil, errors.New("standup not found")
+	}
+
+	var calendar models.HolidayCalendar
+	if err := s.DB.First(&calendar, calendarID).Error; err != nil {
+		return nil, nil, errors.New("holiday calendar not found")
+	}
+	if calendar.GuildID != standup.GuildID {
+		return nil, nil, errors.New("holiday calendar belongs to a different server")
+	}
+	return &standup, &calendar, nil
+}
+
+func (s *StandupService) calendarStandupIDs(calendarID uint) []uint {
+	var standupIDs []uint
+	s.DB.Table("standup_holiday_calendars").Where("holiday_calendar_id = ?", calendarID).
+		Pluck("standup_id", &standupIDs)
+	return standupIDs
+}
+
+// invalidateCalendarStandups recomputes the schedule of every standup using the calendar.
+func (s *StandupService) invalidateCalendarStandups(calendarID uint) {
+	for _, id := range s.calendarStandupIDs(calendarID) {
+		s.InvalidateStandup(id)
+	}
+}
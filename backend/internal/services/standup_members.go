@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+	"gorm.io/gorm"
+)
+
+// Role returns the role the user holds on the standup, or "" if they have none.
+func (s *StandupService) Role(standup models.Standup, userID string) string {
+	if userID == "" {
+		return ""
+	}
+	if standup.ManagerID == userID {
+		return models.RoleManager
+	}
+
+	var member models.StandupMember
+	if err := s.DB.Where("standup_id = ? AND user_id = ?", standup.ID, userID).First(&member).Error; err == nil {
+		return member.Role
+	}
+
+	var count int64
+	s.DB.Table("standup_participants").
+		Joins("JOIN user_profiles ON user_profiles.id = standup_participants.user_profile_id").
+		Where("standup_participants.standup_id = ? AND user_profiles.user_id = ?", standup.ID, userID).
+		Count(&count)
+	if count > 0 {
+		return models.RoleParticipant
+	}
+	return ""
+}
+
+// Authorize reports whether the user may act on the standup at the needed access level.
+// Every permission check on a standup goes through here. Server administrators may do
+// anything. Callers decide that with IsServerAdmin, the bot from the interaction's
+// permissions and the API from the member's channel permissions.
+func (s *StandupService) Authorize(standup models.Standup, userID string, admin bool, need models.Access) bool {
+	if admin {
+		return true
+	}
+	return models.RoleAccess(s.Role(standup, userID)) >= need
+}
+
+// IsServerAdmin reports whether a member's Discord permissions make them a server
+// administrator for Authorize. The bot and the API both ask here, so someone has the same
+// rights in Discord as on the dashboard.
+func IsServerAdmin(permissions int64) bool {
+	return permissions&discordgo.PermissionAdministrator != 0
+}
+
+// AccessibleStandups is a condition matching the standups the user has at least the
+// needed access to through a role. It ignores server administrator rights.
+func (s *StandupService) AccessibleStandups(userID string, need models.Access) *gorm.DB {
+	var roles []string
+	for _, role := range []string{models.RoleCoManager, models.RoleObserver} {
+		if models.RoleAccess(role) >= need {
+			roles = append(roles, role)
+		}
+	}
+
+	scope := s.DB.Where("standups.manager_id = ?", userID)
+	if len(roles) > 0 {
+		scope = scope.Or("standups.id IN (?)", s.DB.Model(&models.StandupMember{}).
+			Select("standup_id").Where("user_id = ? AND role IN ?", userID, roles))
+	}
+	if need <= models.AccessOwnReports {
+		scope = scope.Or("standups.id IN (?)", s.DB.Table("standup_participants").
+			Select("standup_participants.standup_id").
+			Joins("JOIN user_profiles ON user_profiles.id = standup_participants.user_profile_id").
+			Where("user_profiles.user_id = ?", userID))
+	}
+	return scope
+}
+
+// GetMembers lists the standup's co-managers and observers.
+func (s *StandupService) GetMembers(standupID uint) ([]models.StandupMember, error) {
+	var members []models.StandupMember
+	err := s.DB.Where("standup_id = ?", standupID).Order("role asc, created_at asc").Find(&members).Error
+	return members, err
+}
+
+// managerIDs returns everyone who manages the standup: the manager first, then its
+// co-managers.
+func (s *StandupService) managerIDs(standup models.Standup) []string {
+	var ids []string
+	if standup.ManagerID != "" {
+		ids = append(ids, standup.ManagerID)
+	}
+	var coManagers []string
+	s.DB.Model(&models.StandupMember{}).Where("standup_id = ? AND role = ?", standup.ID, models.RoleCoManager).
+		Pluck("user_id", &coManagers)
+	return append(ids, coManagers...)
+}
+
+// GrantRole makes the user a co-manager or observer of the standup, replacing any role
+// granted before. Managers appoint co-managers; co-managers can add observers.
+func (s *StandupService) GrantRole(standup models.Standup, actorID string, admin bool,
+	userID, role string) error {
+
+	switch role {
+	case models.RoleCoManager:
+		if !s.Authorize(standup, actorID, admin, models.AccessOwn) {
+			return errors.New("only the standup's manager or a server admin can appoint co-managers")
+		}
+	case models.RoleObserver:
+		if !s.Authorize(standup, actorID, admin, models.AccessManage) {
+			return errors.New("only the standup's managers or a server admin can add observers")
+		}
+	default:
+		return fmt.Errorf("role must be %s or %s", models.RoleCoManager, models.RoleObserver)
+	}
+	if userID == "" {
+		return errors.New("no user given")
+	}
+	if userID == standup.ManagerID {
+		return errors.New("that user already manages this standup")
+	}
+
+	member := models.StandupMember{StandupID: standup.ID, UserID: userID}
+	if err := s.DB.Where(member).Assign(models.StandupMember{Role: role, GrantedBy: actorID}).
+		FirstOrCreate(&member).Error; err != nil {
+		return err
+	}
+
+	if dmChannel, err := s.Session.UserChannelCreate(userID); err == nil {
+		s.Session.ChannelMessageSend(dmChannel.ID, fmt.Sprintf("🎖️ <@%s> made you %s of the **%s** standup.",
+			actorID, roleArticle(role), standup.Name))
+	}
+	return nil
+}
+
+// RevokeRole takes the user's co-manager or observer role away. Co-managers can only be
+// removed by the manager, but anyone can give up their own role.
+func (s *StandupService) RevokeRole(standup models.Standup, actorID string, admin bool, userID string) error {
+	var member models.StandupMember
+	if err := s.DB.Where("standup_id = ? AND user_id = ?", standup.ID, userID).First(&member).Error; err != nil {
+		return errors.New("that user has no role to revoke on this standup")
+	}
+
+	need := models.AccessManage
+	if member.Role == models.RoleCoManager {
+		need = models.AccessOwn
+	}
+	if actorID != userID && !s.Authorize(standup, actorID, admin, need) {
+		return fmt.Errorf("you can't remove a %s from this standup", models.RoleName(member.Role))
+	}
+
+	return s.DB.Delete(&member).Error
+}
+
+// TransferOwnership hands the standup to another user. The previous manager stays on as
+// a co-manager so nobody loses access along the way.
+func (s *StandupService) TransferOwnership(standup models.Standup, actorID string, admin bool,
+	newManagerID string) error {
+
+	if !s.Authorize(standup, actorID, admin, models.AccessOwn) {
+		return errors.New("only the standup's manager or a server admin can transfer it")
+	}
+	if newManagerID == "" || newManagerID == standup.ManagerID {
+		return errors.New("that user already manages this standup")
+	}
+
+	previous := standup.ManagerID
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("standup_id = ? AND user_id = ?", standup.ID, newManagerID).
+			Delete(&models.StandupMember{}).Error; err != nil {
+			return err
+		}
+		if previous != "" {
+			member := models.StandupMember{StandupID: standup.ID, UserID: previous}
+			if err := tx.Where(member).Assign(models.StandupMember{Role: models.RoleCoManager, GrantedBy: actorID}).
+				FirstOrCreate(&member).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&standup).Update("manager_id", newManagerID).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// Standup-wide events follow the manager's clock.
+	s.InvalidateStandup(standup.ID)
+
+	if dmChannel, err := s.Session.UserChannelCreate(newManagerID); err == nil {
+		s.Session.ChannelMessageSend(dmChannel.ID, fmt.Sprintf("👑 <@%s> made you the manager of the **%s** standup.",
+			actorID, standup.Name))
+	}
+	return nil
+}
+
+func roleArticle(role string) string {
+	if role == models.RoleObserver {
+		return "an observer"
+	}
+	return "a co-manager"
+}
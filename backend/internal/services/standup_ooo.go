@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+const maxOutOfOfficeDays = 365
+
+// AddOutOfOffice registers a date range during which the scheduler records the user as
+// out of office instead of prompting them.
+func (s *StandupService) AddOutOfOffice(entry models.OutOfOffice) (*models.OutOfOffice, error) {
+	if entry.UserID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+
+	start, err := time.Parse("2006-01-02", entry.StartDate)
+	if err != nil {
+		return nil, errors.New("start date must use YYYY-MM-DD")
+	}
+	end, err := time.Parse("2006-01-02", entry.EndDate)
+	if err != nil {
+		return nil, errors.New("end date must use YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return nil, errors.New("end date cannot be before the start date")
+	}
+	if end.Sub(start) > maxOutOfOfficeDays*24*time.Hour {
+		return nil, errors.New("out of office ranges are limited to one year")
+	}
+
+	var profile models.UserProfile
+	if err := s.DB.Preload("Standups").Where("user_id = ?", entry.UserID).First(&profile).Error; err != nil {
+		return nil, errors.New("user profile not found")
+	}
+
+	today := time.Now().In(loadLocation(make(map[string]*time.Location), profile.Timezone, entry.UserID)).
+		Format("2006-01-02")
+	if entry.EndDate < today {
+		return nil, errors.New("that date range is already in the past")
+	}
+
+	if entry.StandupID != 0 {
+		isMember := false
+		for _, st := range profile.Standups {
+			if st.ID == entry.StandupID {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			return nil, errors.New("you are not a member of that standup")
+		}
+	}
+
+	if err := s.DB.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetOutOfOffice lists the user's ranges that haven't ended yet, soonest first.
+func (s *StandupService) GetOutOfOffice(userID string) ([]models.OutOfOffice, error) {
+	var profile models.UserProfile
+	s.DB.Where("user_id = ?", userID).First(&profile)
+	today := time.Now().In(loadLocation(make(map[string]*time.Location), profile.Timezone, userID)).
+		Format("2006-01-02")
+
+	var entries []models.OutOfOffice
+	err := s.DB.Where("user_id = ? AND end_date >= ?", userID, today).
+		Order("start_date asc").Find(&entries).Error
+	return entries, err
+}
+
+func (s *StandupService) DeleteOutOfOffice(userID string, id uint) error {
+	result := s.DB.Where("id = ? AND user_id = ?", id, userID).Delete(&models.OutOfOffice{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("out of office entry not found")
+	}
+	return nil
+}
+
+// IsOutOfOffice reports whether date (YYYY-MM-DD in the user's timezone) falls inside one
+// of the user's ranges for the standup.
+func (s *StandupService) IsOutOfOffice(userID string, standupID uint, date string) bool {
+	var count int64
+	if err := s.DB.Model(&models.OutOfOffice{}).
+		Where("user_id = ? AND (standup_id = 0 OR standup_id = ?) AND start_date <= ? AND end_date >= ?",
+			userID, standupID, date, date).
+		Count(&count).Error; err != nil {
+		log.Printf("Error checking out of office for %s: %v", userID, err)
+		return false
+	}
+	return count > 0
+}
+
+// recordOutOfOffice stands in for the day's report so summaries, digests and the
+// dashboard show the absence rather than a missing update.
+func (s *StandupService) recordOutOfOffice(standup models.Standup, userID, date string) {
+	// A report or skip already stands for the day.
+	if s.DayEntry(userID, standup.ID, date) != nil {
+		return
+	}
+
+	history := models.StandupHistory{
+		UserID:    userID,
+		StandupID: standup.ID,
+		Date:      date,
+		Answers:   []string{models.OutOfOfficeAnswer},
+	}
+	if err := s.DB.Create(&history).Error; err != nil {
+		log.Printf("Error recording out of office for %s in standup %d: %v", userID, standup.ID, err)
+		return
+	}
+
+	log.Printf("🌴 %s is out of office for standup: %s", userID, standup.Name)
+	if standup.IsDigest() {
+		s.UpdateDigest(standup, history)
+	}
+	s.RefreshThreadSummary(standup, userID, date)
+}
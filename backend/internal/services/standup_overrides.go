@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"gorm.io/gorm"
+)
+
+var weekdayNames = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// participantRow is a standup_participants row together with the Discord ID it belongs to.
+type participantRow struct {
+	models.StandupParticipant
+	UserID string
+}
+
+func (s *StandupService) participantRows() *gorm.DB {
+	return s.DB.Table("standup_participants").
+		Select("standup_participants.*, user_profiles.user_id").
+		Joins("JOIN user_profiles ON user_profiles.id = standup_participants.user_profile_id")
+}
+
+// ParticipantOverride returns the member's own time and days for the standup. Empty fields
+// mean the standup's settings apply.
+func (s *StandupService) ParticipantOverride(standupID uint, userID string) models.StandupParticipant {
+	var row participantRow
+	s.participantRows().
+		Where("standup_participants.standup_id = ? AND user_profiles.user_id = ?", standupID, userID).
+		Scan(&row)
+	return row.StandupParticipant
+}
+
+// StandupOverrides returns every override set on the standup, keyed by Discord user ID.
+func (s *StandupService) StandupOverrides(standupID uint) map[string]models.StandupParticipant {
+	var rows []participantRow
+	s.participantRows().
+		Where("standup_participants.standup_id = ? AND (standup_participants.time <> '' OR standup_participants.days <> '')",
+			standupID).
+		Scan(&rows)
+
+	overrides := make(map[string]models.StandupParticipant, len(rows))
+	for _, row := range rows {
+		overrides[row.UserID] = row.StandupParticipant
+	}
+	return overrides
+}
+
+// allParticipantOverrides is StandupOverrides for every standup at once, keyed by
+// scheduleKey, used by full rebuilds.
+func (s *StandupService) allParticipantOverrides() (map[string]models.StandupParticipant, error) {
+	var rows []participantRow
+	if err := s.participantRows().
+		Where("standup_participants.time <> '' OR standup_participants.days <> ''").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]models.StandupParticipant, len(rows))
+	for _, row := range rows {
+		overrides[scheduleKey(row.StandupID, row.UserID)] = row.StandupParticipant
+	}
+	return overrides, nil
+}
+
+// SetParticipantOverride stores a member's own trigger time and active days. Passing
+// empty strings clears the override so the standup's settings apply again.
+func (s *StandupService) SetParticipantOverride(standupID uint, userID string,
+	override models.StandupParticipant) error {
+
+	normalized, err := normalizeOverride(override)
+	if err != nil {
+		return err
+	}
+
+	var user models.UserProfile
+	if err := s.DB.Where("user_id = ?", userID).First(&user).Error; err != nil {
+		return errors.New("user is not a member of this standup")
+	}
+
+	result := s.DB.Model(&models.StandupParticipant{}).
+		Where("standup_id = ? AND user_profile_id = ?", standupID, user.ID).
+		Updates(map[string]interface{}{"time": normalized.Time, "days": normalized.Days})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user is not a member of this standup")
+	}
+
+	s.InvalidateParticipant(userID, standupID)
+	return nil
+}
+
+func normalizeOverride(override models.StandupParticipant) (models.StandupParticipant, error) {
+	if override.Time != "" {
+		hour, minute, err := parseStandupTime(override.Time)
+		if err != nil {
+			return override, errors.New("time must use HH:MM in 24h format")
+		}
+		override.Time = fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+
+	if override.Days != "" {
+		days, err := normalizeDays(override.Days)
+		if err != nil {
+			return override, err
+		}
+		override.Days = days
+	}
+	return override, nil
+}
+
+// normalizeDays turns "mon, wed,Friday" into the "Monday,Wednesday,Friday" form used by
+// Standup.Days.
+func normalizeDays(raw string) (string, error) {
+	selected := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		found := false
+		for _, day := range weekdayNames {
+			if len(part) >= 3 && strings.HasPrefix(strings.ToLower(day), strings.ToLower(part)) {
+				selected[day] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("unknown day %q", part)
+		}
+	}
+
+	var days []string
+	for _, day := range weekdayNames {
+		if selected[day] {
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		return "", errors.New("at least one day is required")
+	}
+	return strings.Join(days, ","), nil
+}
+
+// withOverride returns the standup as seen by one participant.
+func withOverride(standup models.Standup, override models.StandupParticipant) models.Standup {
+	if override.Time != "" {
+		standup.Time = override.Time
+	}
+	if override.Days != "" {
+		standup.Days = override.Days
+	}
+	return standup
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+// PauseStandup puts the standup on hold, e.g. for an offsite or a code freeze. With a
+// resumeOn date (YYYY-MM-DD) it comes back on by itself that day; without one it stays
+// paused until ResumeStandup. Pausing a paused standup updates its reason and date.
+func (s *StandupService) PauseStandup(standup models.Standup, actorID, reason, resumeOn string) error {
+	resumeOn = strings.TrimSpace(resumeOn)
+	if resumeOn != "" {
+		day, err := time.Parse("2006-01-02", resumeOn)
+		if err != nil {
+			return errors.New("resume date must use YYYY-MM-DD")
+		}
+		today := time.Now().In(s.standupLocation(standup))
+		today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+		if !day.After(today) {
+			return errors.New("resume date must be after today")
+		}
+		if day.After(today.AddDate(1, 0, 0)) {
+			return errors.New("resume date can be at most a year away")
+		}
+	}
+
+	pausedAt := time.Now().UTC()
+	if standup.PausedAt != nil {
+		pausedAt = *standup.PausedAt
+	}
+	err := s.DB.Model(&standup).Updates(map[string]interface{}{
+		"paused_at":    pausedAt,
+		"paused_by":    actorID,
+		"pause_reason": strings.TrimSpace(reason),
+		"resume_on":    resumeOn,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	s.InvalidateStandup(standup.ID)
+	return nil
+}
+
+// ResumeStandup takes the standup off hold and lets its members know. actorID is empty
+// when it resumes on its own.
+func (s *StandupService) ResumeStandup(standup models.Standup, actorID string) error {
+	if !standup.IsPaused() {
+		return errors.New("this standup isn't paused")
+	}
+
+	err := s.DB.Model(&standup).Updates(map[string]interface{}{
+		"paused_at":    nil,
+		"paused_by":    "",
+		"pause_reason": "",
+		"resume_on":    "",
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	s.InvalidateStandup(standup.ID)
+	s.notifyResumed(standup, actorID)
+	return nil
+}
+
+// fireResume brings a paused standup back on once its resume date arrives.
+func (s *StandupService) fireResume(standup models.Standup, now time.Time) {
+	dueAt := resumeAt(standup)
+	if dueAt.IsZero() || dueAt.After(now) {
+		// Resumed by hand, or paused again with a new date, after this entry was queued.
+		s.scheduler().Set(entryResume, standup.ID, "", dueAt)
+		return
+	}
+
+	if err := s.ResumeStandup(standup, ""); err != nil {
+		log.Printf("Error resuming standup %d: %v", standup.ID, err)
+		return
+	}
+	log.Printf("▶️ Resumed standup %s", standup.Name)
+}
+
+func (s *StandupService) notifyResumed(standup models.Standup, actorID string) {
+	var participants []models.UserProfile
+	if err := s.DB.Model(&standup).Association("Participants").Find(&participants); err != nil {
+		log.Printf("Error loading participants of standup %d: %v", standup.ID, err)
+		return
+	}
+
+	msg := fmt.Sprintf("▶️ The **%s** standup is back on. Your prompts start again on their usual schedule.",
+		standup.Name)
+	if actorID != "" {
+		msg = fmt.Sprintf("▶️ <@%s> resumed the **%s** standup. Your prompts start again on their usual schedule.",
+			actorID, standup.Name)
+	}
+
+	for _, user := range participants {
+		if dmChannel, err := s.Session.UserChannelCreate(user.UserID); err == nil {
+			s.Session.ChannelMessageSend(dmChannel.ID, msg)
+		}
+	}
+}
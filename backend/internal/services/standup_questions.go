@@ -0,0 +1,459 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+)
+
+const (
+	maxAnswerLength    = 4000
+	maxQuestionChoices = 25
+	maxChoiceLength    = 100
+)
+
+// QuestionStat aggregates the typed answers given to one question over a date range.
+// Average is the mean score for scale questions and the share of "Yes" for yes/no ones.
+type QuestionStat struct {
+	QuestionIndex int            `json:"question_index"`
+	Question      string         `json:"question"`
+	Type          string         `json:"type"`
+	Responses     int            `json:"responses"`
+	Average       *float64       `json:"average,omitempty"`
+	Counts        map[string]int `json:"counts"`
+}
+
+// normalizeQuestionSpecs lines the specs up with the questions, one per question, and
+// checks each one. Questions without a spec become required free text.
+func normalizeQuestionSpecs(standup *models.Standup) error {
+	specs := make([]models.QuestionSpec, len(standup.Questions))
+	blockers := 0
+	ids := make(map[string]bool)
+	for i := range specs {
+		spec := standup.QuestionSpec(i)
+		if err := normalizeQuestionSpec(&spec); err != nil {
+			return fmt.Errorf("question %d: %w", i+1, err)
+		}
+		if spec.ID == "" || ids[spec.ID] {
+			spec.ID = models.NewQuestionID()
+		}
+		ids[spec.ID] = true
+		if spec.Blocker {
+			if spec.IsTyped() {
+				return fmt.Errorf("question %d: only text questions can track blockers", i+1)
+			}
+			if blockers++; blockers > 1 {
+				return errors.New("only one question can be the blocker question")
+			}
+		}
+		if spec.ShowIf != nil {
+			condition := *spec.ShowIf
+			if err := normalizeCondition(&condition, i, specs); err != nil {
+				return fmt.Errorf("question %d: %w", i+1, err)
+			}
+			spec.ShowIf = &condition
+		}
+		specs[i] = spec
+	}
+
+	// Links can point at later questions, so they are checked once every spec is known.
+	for i, spec := range specs {
+		if spec.CarryFrom == nil {
+			continue
+		}
+		from := *spec.CarryFrom
+		if from < 0 || from >= len(specs) || from == i {
+			return fmt.Errorf("question %d: can only carry over another question's answer", i+1)
+		}
+		if spec.IsTyped() || specs[from].IsTyped() {
+			return fmt.Errorf("question %d: only text answers can be carried over", i+1)
+		}
+	}
+	standup.QuestionSpecs = specs
+	return nil
+}
+
+// normalizeCondition checks that a follow-up depends on an earlier question and, when
+// that question has fixed answers, only on answers it can actually give.
+func normalizeCondition(condition *models.QuestionCondition, i int, earlier []models.QuestionSpec) error {
+	if condition.Question < 0 || condition.Question >= i {
+		return errors.New("follow-ups can only depend on an earlier question")
+	}
+
+	var allowed []string
+	target := earlier[condition.Question]
+	switch target.Type {
+	case models.QuestionSelect:
+		allowed = target.Choices
+	case models.QuestionYesNo:
+		allowed = []string{"Yes", "No"}
+	case models.QuestionScale:
+		for score := models.ScaleMin; score <= models.ScaleMax; score++ {
+			allowed = append(allowed, strconv.Itoa(score))
+		}
+	}
+
+	var answers []string
+	for _, want := range condition.Answers {
+		want = strings.TrimSpace(want)
+		if want == "" {
+			continue
+		}
+		if allowed != nil {
+			match := ""
+			for _, answer := range allowed {
+				if strings.EqualFold(answer, want) {
+					match = answer
+				}
+			}
+			if match == "" {
+				return fmt.Errorf("%q is not an answer to question %d", want, condition.Question+1)
+			}
+			want = match
+		}
+		answers = append(answers, want)
+	}
+	condition.Answers = answers
+	return nil
+}
+
+// RemapQuestions replaces the standup's questions. from[i] is the old position of the new
+// i-th question, or -1 for a new one; specs follow their question and follow-up conditions
+// and carry-over links are repointed. A follow-up whose question was removed is always
+// asked, and a link to a removed question is dropped.
+func RemapQuestions(standup *models.Standup, questions []string, from []int) {
+	newIndex := make(map[int]int, len(from))
+	for i, old := range from {
+		if old >= 0 {
+			newIndex[old] = i
+		}
+	}
+
+	specs := make([]models.QuestionSpec, len(questions))
+	for i, old := range from {
+		if old < 0 || old >= len(standup.Questions) {
+			continue
+		}
+		spec := standup.QuestionSpec(old)
+		if spec.ShowIf != nil {
+			condition := *spec.ShowIf
+			spec.ShowIf = nil
+			if to, ok := newIndex[condition.Question]; ok && to < i {
+				condition.Question = to
+				spec.ShowIf = &condition
+			}
+		}
+		if spec.CarryFrom != nil {
+			to, ok := newIndex[*spec.CarryFrom]
+			spec.CarryFrom = nil
+			if ok && to != i {
+				spec.CarryFrom = &to
+			}
+		}
+		specs[i] = spec
+	}
+
+	standup.Questions = questions
+	standup.QuestionSpecs = specs
+}
+
+// CheckQuestionLinks rejects specs sent back for moved questions whose follow-up condition
+// or carry-over link still uses the old positions. from is as in RemapQuestions. A link
+// left exactly as it was must point where RemapQuestions would repoint it; links the
+// caller changed are taken as written for the new order.
+func CheckQuestionLinks(standup models.Standup, questions []string, specs []models.QuestionSpec, from []int) error {
+	remapped := standup
+	RemapQuestions(&remapped, questions, from)
+
+	for i, old := range from {
+		if old < 0 || old >= len(standup.Questions) || i >= len(specs) {
+			continue
+		}
+		before, after, sent := standup.QuestionSpec(old), remapped.QuestionSpecs[i], specs[i]
+
+		if before.ShowIf != nil && sent.ShowIf != nil &&
+			sent.ShowIf.Question == before.ShowIf.Question && slices.Equal(sent.ShowIf.Answers, before.ShowIf.Answers) &&
+			(after.ShowIf == nil || after.ShowIf.Question != sent.ShowIf.Question) {
+			return ValidationError{fmt.Errorf("question %d is still a follow-up to question %d, "+
+				"which has moved or been removed; update the condition to match the new order", i+1, sent.ShowIf.Question+1)}
+		}
+		if before.CarryFrom != nil && sent.CarryFrom != nil && *sent.CarryFrom == *before.CarryFrom &&
+			(after.CarryFrom == nil || *after.CarryFrom != *sent.CarryFrom) {
+			return ValidationError{fmt.Errorf("question %d still starts from the answer to question %d, "+
+				"which has moved or been removed; update the link to match the new order", i+1, *sent.CarryFrom+1)}
+		}
+	}
+	return nil
+}
+
+func normalizeQuestionSpec(spec *models.QuestionSpec) error {
+	switch spec.Type {
+	case models.QuestionText, models.QuestionShort:
+		spec.Choices = nil
+		if spec.MinLength < 0 || spec.MaxLength < 0 ||
+			spec.MinLength > maxAnswerLength || spec.MaxLength > maxAnswerLength {
+			return fmt.Errorf("length limits must be between 0 and %d", maxAnswerLength)
+		}
+		if spec.MaxLength > 0 && spec.MinLength > spec.MaxLength {
+			return errors.New("minimum length is above the maximum")
+		}
+
+	case models.QuestionSelect:
+		spec.MinLength, spec.MaxLength = 0, 0
+		seen := make(map[string]bool)
+		var choices []string
+		for _, choice := range spec.Choices {
+			choice = strings.TrimSpace(choice)
+			if choice == "" || seen[strings.ToLower(choice)] {
+				continue
+			}
+			if len(choice) > maxChoiceLength {
+				return fmt.Errorf("choices are limited to %d characters", maxChoiceLength)
+			}
+			seen[strings.ToLower(choice)] = true
+			choices = append(choices, choice)
+		}
+		if len(choices) < 2 || len(choices) > maxQuestionChoices {
+			return fmt.Errorf("multiple choice questions need 2 to %d choices", maxQuestionChoices)
+		}
+		spec.Choices = choices
+
+	case models.QuestionYesNo, models.QuestionScale:
+		spec.MinLength, spec.MaxLength = 0, 0
+		spec.Choices = nil
+
+	default:
+		return fmt.Errorf("unknown question type %q", spec.Type)
+	}
+	return nil
+}
+
+// ParseAnswer checks an answer against its question's spec and returns the value to
+// store: the choice as written, "Yes" or "No", or the score. Choices can also be picked
+// by their 1-based number. Every way of answering or editing a report goes through here,
+// so the stats and blockers only ever see values their question allows.
+func ParseAnswer(spec models.QuestionSpec, answer string) (string, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		if spec.Optional {
+			return "", nil
+		}
+		return "", errors.New("This question needs an answer.")
+	}
+
+	switch spec.Type {
+	case models.QuestionSelect:
+		for _, choice := range spec.Choices {
+			if strings.EqualFold(choice, answer) {
+				return choice, nil
+			}
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(spec.Choices) {
+			return spec.Choices[n-1], nil
+		}
+		return "", errors.New("Please pick one of the listed choices.")
+
+	case models.QuestionYesNo:
+		switch strings.ToLower(answer) {
+		case "yes", "y":
+			return "Yes", nil
+		case "no", "n":
+			return "No", nil
+		}
+		return "", errors.New("Please answer `yes` or `no`.")
+
+	case models.QuestionScale:
+		score, err := strconv.Atoi(answer)
+		if err != nil || score < models.ScaleMin || score > models.ScaleMax {
+			return "", fmt.Errorf("Please answer with a number from %d to %d.", models.ScaleMin, models.ScaleMax)
+		}
+		return strconv.Itoa(score), nil
+	}
+
+	length := utf8.RuneCountInString(answer)
+	switch {
+	case spec.MinLength > 0 && length < spec.MinLength:
+		return "", fmt.Errorf("Please write at least %d characters.", spec.MinLength)
+	case spec.MaxLength > 0 && length > spec.MaxLength:
+		return "", fmt.Errorf("Please keep it under %d characters.", spec.MaxLength)
+	case length > maxAnswerLength:
+		return "", fmt.Errorf("Please keep it under %d characters.", maxAnswerLength)
+	}
+	return answer, nil
+}
+
+// parseAnswers runs ParseAnswer over the answers that differ from previous, using the
+// spec of the question each one was given to. Answers left as they were are kept even if
+// the question's rules have tightened since.
+func parseAnswers(standup models.Standup, questions []models.AskedQuestion, previous, answers []string) error {
+	for i, answer := range answers {
+		if i < len(previous) && previous[i] == answer {
+			continue
+		}
+
+		spec := models.QuestionSpec{Type: models.QuestionText, Optional: true}
+		if i < len(questions) {
+			spec.Type = questions[i].Type
+			if pos, ok := standup.QuestionPosition(questions[i].ID); ok && questions[i].ID != "" &&
+				standup.QuestionSpec(pos).Type == questions[i].Type {
+				spec = standup.QuestionSpec(pos)
+			}
+		}
+
+		value, err := ParseAnswer(spec, answer)
+		if err != nil {
+			text := fmt.Sprintf("answer %d", i+1)
+			if i < len(questions) {
+				text = questions[i].Text
+			}
+			return fmt.Errorf("%s: %w", text, err)
+		}
+		answers[i] = value
+	}
+	return nil
+}
+
+// RecordAnswers stores the typed answers of a submission so they can be aggregated.
+// Free-text answers only live on the history row.
+func (s *StandupService) RecordAnswers(standup models.Standup, history models.StandupHistory) {
+	var answers []models.StandupAnswer
+	for i, value := range history.Answers {
+		q, ok := history.CurrentIndex(standup, i)
+		question := history.AskedQuestion(standup, i)
+		spec := question.Spec()
+		if !ok || !spec.IsTyped() || value == "" {
+			continue
+		}
+
+		answer := models.StandupAnswer{
+			HistoryID:     history.ID,
+			StandupID:     standup.ID,
+			Date:          history.Date,
+			UserID:        history.UserID,
+			QuestionIndex: q,
+			QuestionID:    question.ID,
+			Question:      question.Text,
+			Type:          spec.Type,
+			Value:         value,
+		}
+		switch spec.Type {
+		case models.QuestionScale:
+			if score, err := strconv.Atoi(value); err == nil {
+				answer.Score = &score
+			}
+		case models.QuestionYesNo:
+			score := 0
+			if value == "Yes" {
+				score = 1
+			}
+			answer.Score = &score
+		}
+		answers = append(answers, answer)
+	}
+
+	if len(answers) == 0 {
+		return
+	}
+	if err := s.DB.Create(&answers).Error; err != nil {
+		log.Printf("Error saving typed answers for standup %d: %v", standup.ID, err)
+	}
+}
+
+// QuestionStats aggregates the answers to each of the standup's typed questions between
+// two dates, inclusive. Answers are matched by question ID, so they stay with their
+// question when it is reworded or moved.
+func (s *StandupService) QuestionStats(standupID uint, from, to string) ([]QuestionStat, error) {
+	var standup models.Standup
+	if err := s.DB.First(&standup, standupID).Error; err != nil {
+		return nil, errors.New("standup not found")
+	}
+
+	var answers []models.StandupAnswer
+	if err := s.DB.Where("standup_id = ? AND date >= ? AND date <= ?", standupID, from, to).
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return questionStats(standup, answers), nil
+}
+
+func questionStats(standup models.Standup, answers []models.StandupAnswer) []QuestionStat {
+	byQuestion := make(map[string][]models.StandupAnswer)
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = append(byQuestion[answer.QuestionID], answer)
+	}
+
+	var stats []QuestionStat
+	for i, question := range standup.Questions {
+		spec := standup.QuestionSpec(i)
+		if !spec.IsTyped() || spec.ID == "" {
+			continue
+		}
+
+		stat := QuestionStat{
+			QuestionIndex: i,
+			Question:      question,
+			Type:          spec.Type,
+			Counts:        make(map[string]int),
+		}
+
+		total, scored := 0, 0
+		for _, answer := range byQuestion[spec.ID] {
+			if answer.Type != spec.Type {
+				continue
+			}
+			stat.Responses++
+			stat.Counts[answer.Value]++
+			if answer.Score != nil {
+				total += *answer.Score
+				scored++
+			}
+		}
+		if scored > 0 {
+			average := float64(total) / float64(scored)
+			stat.Average = &average
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// FormatQuestionStat summarises a stat in one line for Discord, e.g. "3.8 / 5 from 6
+// answers" or "Yes 4 · No 2".
+func FormatQuestionStat(stat QuestionStat) string {
+	if stat.Responses == 0 {
+		return "*No answers*"
+	}
+
+	switch stat.Type {
+	case models.QuestionScale:
+		if stat.Average != nil {
+			return fmt.Sprintf("**%.1f / %d** from %d answers", *stat.Average, models.ScaleMax, stat.Responses)
+		}
+	case models.QuestionYesNo:
+		return fmt.Sprintf("Yes **%d** · No **%d**", stat.Counts["Yes"], stat.Counts["No"])
+	}
+
+	values := make([]string, 0, len(stat.Counts))
+	for value := range stat.Counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(a, b int) bool {
+		if stat.Counts[values[a]] != stat.Counts[values[b]] {
+			return stat.Counts[values[a]] > stat.Counts[values[b]]
+		}
+		return values[a] < values[b]
+	})
+
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%s **%d**", value, stat.Counts[value])
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// ReportEmbed renders one person's submission the way it is posted to the report channel.
+func ReportEmbed(standup models.Standup, history models.StandupHistory,
+	userName, avatarURL string) *discordgo.MessageEmbed {
+
+	var fields []*discordgo.MessageEmbedField
+	for i, answer := range history.Answers {
+		question := history.AskedQuestion(standup, i)
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   truncateText(question.Text, maxEmbedFieldName),
+			Value:  truncateText("👉 "+question.Spec().Display(answer), maxEmbedFieldValue),
+			Inline: false,
+		})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    fmt.Sprintf("%s's Standup", userName),
+			IconURL: avatarURL,
+		},
+		Title:       fmt.Sprintf("🚀 %s Update", standup.Name),
+		Description: fmt.Sprintf("Progress report from **%s**", userName),
+		Color:       0x5865F2,
+		Fields:      fields,
+		Timestamp:   history.CreatedAt.Format(time.RFC3339),
+	}
+	if history.EditedAt != nil {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: "✏️ Edited " + history.EditedAt.UTC().Format("2006-01-02 15:04 UTC"),
+		}
+	}
+	return embed
+}
+
+// DayEntry returns the member's report, or skipped/out-of-office marker, for the standup
+// on date, or nil if they have neither.
+func (s *StandupService) DayEntry(userID string, standupID uint, date string) *models.StandupHistory {
+	var history models.StandupHistory
+	if err := s.DB.Where("user_id = ? AND standup_id = ? AND date = ?", userID, standupID, date).
+		First(&history).Error; err != nil {
+		return nil
+	}
+	return &history
+}
+
+// SubmitReport stores a finished report and shares it. A member has one entry per day: a
+// second submission replaces their earlier report as an edit would, and a report after
+// skipping takes the skip's place. It reports whether an earlier report was replaced.
+func (s *StandupService) SubmitReport(standup models.Standup, history *models.StandupHistory,
+	userName, avatarURL string) (bool, error) {
+
+	history.Questions = models.SnapshotQuestions(standup, history.Asked, len(history.Answers))
+
+	existing := s.DayEntry(history.UserID, standup.ID, history.Date)
+	if existing != nil && !existing.IsMarker() {
+		answers, asked, questions := history.Answers, history.Asked, history.Questions
+		*history = *existing
+		return true, s.UpdateReport(history, answers, asked, questions)
+	}
+
+	if existing != nil {
+		if existing.MessageID != "" {
+			if err := s.Session.ChannelMessageDelete(existing.ChannelID, existing.MessageID); err != nil {
+				log.Printf("Warning: Failed to delete skip message %s: %v", existing.MessageID, err)
+			}
+		}
+		existing.Answers, existing.Asked, existing.Questions = history.Answers, history.Asked, history.Questions
+		existing.ChannelID, existing.MessageID = "", ""
+		if err := s.DB.Save(existing).Error; err != nil {
+			return false, err
+		}
+		*history = *existing
+	} else if err := s.DB.Create(history).Error; err != nil {
+		return false, err
+	}
+
+	s.RecordAnswers(standup, *history)
+	s.TrackBlocker(standup, *history)
+
+	if standup.IsDigest() {
+		s.UpdateDigest(standup, *history)
+		return false, nil
+	}
+	s.PostReport(standup, history, userName, avatarURL)
+	return false, nil
+}
+
+// PostReport sends a new submission to the report channel, or the day's thread, and
+// remembers the message so it can be edited or retracted later.
+func (s *StandupService) PostReport(standup models.Standup, history *models.StandupHistory,
+	userName, avatarURL string) {
+
+	channelID := s.ReportChannel(standup, history.UserID, history.Date)
+	msg, err := s.Session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content:    fmt.Sprintf("<@%s>", history.UserID),
+		Embeds:     []*discordgo.MessageEmbed{ReportEmbed(standup, *history, userName, avatarURL)},
+		Components: s.reportBlockerComponents(history.ID),
+	})
+	if err != nil {
+		log.Printf("Error posting report for standup %d: %v", standup.ID, err)
+		return
+	}
+
+	history.ChannelID = msg.ChannelID
+	history.MessageID = msg.ID
+	s.DB.Model(history).Updates(map[string]interface{}{"channel_id": msg.ChannelID, "message_id": msg.ID})
+	s.RefreshThreadSummary(standup, history.UserID, history.Date)
+}
+
+// CarriedAnswer returns what the user answered to question q in their most recent report
+// for the standup, skipping skipped and out-of-office days. It is empty if they never did.
+func (s *StandupService) CarriedAnswer(userID string, standup models.Standup, q int) string {
+	var histories []models.StandupHistory
+	s.DB.Where("user_id = ? AND standup_id = ?", userID, standup.ID).
+		Order("created_at desc").Limit(10).Find(&histories)
+
+	for _, history := range histories {
+		if history.IsMarker() {
+			continue
+		}
+		answer, _ := history.AnswerToQuestion(standup, q)
+		return answer
+	}
+	return ""
+}
+
+// GetOwnReport loads a submission for editing, making sure it belongs to the user and is
+// an actual report rather than a skipped or out-of-office day.
+func (s *StandupService) GetOwnReport(historyID uint, userID string) (*models.StandupHistory, error) {
+	var history models.StandupHistory
+	if err := s.DB.Where("id = ? AND user_id = ?", historyID, userID).First(&history).Error; err != nil {
+		return nil, errors.New("report not found")
+	}
+	if history.IsMarker() {
+		return nil, errors.New("skipped and out-of-office days have no report to edit")
+	}
+	return &history, nil
+}
+
+// UpdateReport replaces the answers of a submission and updates what was posted for it,
+// marking it as edited. questions is what the answers were given to; when nil they are
+// taken to answer the standup's current questions.
+func (s *StandupService) UpdateReport(history *models.StandupHistory, answers []string, asked []int,
+	questions []models.AskedQuestion) error {
+
+	var standup models.Standup
+	if err := s.DB.First(&standup, history.StandupID).Error; err != nil {
+		return errors.New("standup not found")
+	}
+	if questions == nil {
+		questions = models.SnapshotQuestions(standup, asked, len(answers))
+	}
+	answers = append([]string(nil), answers...)
+	if err := parseAnswers(standup, questions, history.Answers, answers); err != nil {
+		return ValidationError{err}
+	}
+
+	now := time.Now()
+	history.Answers = answers
+	history.Asked = asked
+	history.Questions = questions
+	history.EditedAt = &now
+	if err := s.DB.Save(history).Error; err != nil {
+		return err
+	}
+
+	s.DB.Where("history_id = ?", history.ID).Delete(&models.StandupAnswer{})
+	s.RecordAnswers(standup, *history)
+	s.TrackBlocker(standup, *history)
+
+	if standup.IsDigest() {
+		s.UpdateDigest(standup, *history)
+		return nil
+	}
+	if history.MessageID == "" {
+		return nil
+	}
+
+	var profile models.UserProfile
+	s.DB.Where("user_id = ?", history.UserID).First(&profile)
+	userName := profile.Username
+	if userName == "" {
+		userName = history.UserID
+	}
+
+	embeds := []*discordgo.MessageEmbed{ReportEmbed(standup, *history, userName, profile.Avatar)}
+	components := s.reportBlockerComponents(history.ID)
+	if _, err := s.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         history.MessageID,
+		Channel:    history.ChannelID,
+		Embeds:     &embeds,
+		Components: &components,
+	}); err != nil {
+		log.Printf("Warning: Failed to edit report message %s: %v", history.MessageID, err)
+	}
+	return nil
+}
+
+// RetractReport deletes a submission along with the message posted for it.
+func (s *StandupService) RetractReport(historyID uint, userID string) error {
+	history, err := s.GetOwnReport(historyID, userID)
+	if err != nil {
+		return err
+	}
+
+	if history.MessageID != "" {
+		if err := s.Session.ChannelMessageDelete(history.ChannelID, history.MessageID); err != nil {
+			log.Printf("Warning: Failed to delete report message %s: %v", history.MessageID, err)
+		}
+	}
+
+	s.DB.Where("history_id = ?", history.ID).Delete(&models.StandupAnswer{})
+	s.DB.Where("history_id = ?", history.ID).Delete(&models.Blocker{})
+	if err := s.DB.Unscoped().Delete(history).Error; err != nil {
+		return err
+	}
+
+	var standup models.Standup
+	if err := s.DB.First(&standup, history.StandupID).Error; err == nil {
+		if standup.IsDigest() {
+			s.UpdateDigest(standup, *history)
+		}
+		s.RefreshThreadSummary(standup, history.UserID, history.Date)
+	}
+	return nil
+}
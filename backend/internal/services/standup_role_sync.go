@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/lib/pq"
+)
+
+// guildMembersPage is the most members Discord returns per GuildMembers call.
+const guildMembersPage = 1000
+
+// roleSynced matches standups bound to at least one Discord role.
+const roleSynced = "sync_role_ids IS NOT NULL AND sync_role_ids <> '{}'"
+
+// SyncGuildMember brings one member of a guild in line with every role-synced standup
+// there: holding a synced role adds them, losing it (or leaving the server) removes them
+// again. Only members role syncing added are ever removed, so people added by hand stay.
+func (s *StandupService) SyncGuildMember(guildID, userID string, roles []string, present bool) {
+	var standups []models.Standup
+	if err := s.DB.Where("guild_id = ?", guildID).Where(roleSynced).Find(&standups).Error; err != nil {
+		log.Printf("Role sync: failed to load standups for guild %s: %v", guildID, err)
+		return
+	}
+
+	s.roleSyncMu.Lock()
+	defer s.roleSyncMu.Unlock()
+
+	for _, standup := range standups {
+		wanted := present && holdsAnyRole(roles, standup.SyncRoleIDs)
+		viaRole, member := s.participantSources(standup.ID)[userID]
+		if _, err := s.syncParticipant(standup, userID, wanted, member, viaRole); err != nil {
+			log.Printf("Role sync: failed to sync %s on standup %d: %v", userID, standup.ID, err)
+		}
+	}
+}
+
+// ResyncStandupRoles reconciles the standup's participants with everyone currently holding
+// one of its synced roles, for when events were missed or the roles just changed.
+func (s *StandupService) ResyncStandupRoles(standup models.Standup) (added, removed int, err error) {
+	if len(standup.SyncRoleIDs) == 0 {
+		return 0, 0, errors.New("this standup isn't synced with any role")
+	}
+	return s.reconcileRoles(standup)
+}
+
+// SetSyncRoles binds the standup to exactly the given roles and reconciles its members.
+// Members a dropped role brought in leave unless another synced role keeps them.
+func (s *StandupService) SetSyncRoles(standup models.Standup, roleIDs []string) (added, removed int, err error) {
+	if standup.GuildID == "" {
+		return 0, 0, errors.New("this standup isn't linked to a server")
+	}
+
+	roles := pq.StringArray{}
+	seen := make(map[string]bool)
+	for _, id := range roleIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			roles = append(roles, id)
+		}
+	}
+
+	if err := s.DB.Model(&standup).Update("sync_role_ids", roles).Error; err != nil {
+		return 0, 0, err
+	}
+	standup.SyncRoleIDs = roles
+	return s.reconcileRoles(standup)
+}
+
+func (s *StandupService) reconcileRoles(standup models.Standup) (added, removed int, err error) {
+	holders := make(map[string]bool)
+	if len(standup.SyncRoleIDs) > 0 {
+		if holders, err = s.roleHolders(standup.GuildID, standup.SyncRoleIDs); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	s.roleSyncMu.Lock()
+	defer s.roleSyncMu.Unlock()
+
+	sources := s.participantSources(standup.ID)
+	for userID := range holders {
+		viaRole, member := sources[userID]
+		changed, err := s.syncParticipant(standup, userID, true, member, viaRole)
+		if err != nil {
+			return added, removed, err
+		}
+		if changed {
+			added++
+		}
+	}
+	for userID, viaRole := range sources {
+		if holders[userID] {
+			continue
+		}
+		changed, err := s.syncParticipant(standup, userID, false, true, viaRole)
+		if err != nil {
+			return added, removed, err
+		}
+		if changed {
+			removed++
+		}
+	}
+	return added, removed, nil
+}
+
+// syncParticipant adds or removes one user so their membership matches wanted, reporting
+// whether anything changed. member and viaRole describe their current participant row.
+func (s *StandupService) syncParticipant(standup models.Standup, userID string, wanted, member, viaRole bool) (bool, error) {
+	switch {
+	case wanted && !member:
+		err := s.AddMemberToStandup(userID, standup.ID, models.StandupParticipant{ViaRole: true})
+		return err == nil, err
+	case !wanted && member && viaRole:
+		err := s.RemoveMemberFromStandup(userID, standup.ID)
+		return err == nil, err
+	}
+	return false, nil
+}
+
+// participantSources maps each of the standup's participants to whether role syncing
+// added them.
+func (s *StandupService) participantSources(standupID uint) map[string]bool {
+	var rows []participantRow
+	s.participantRows().Where("standup_participants.standup_id = ?", standupID).Scan(&rows)
+
+	sources := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		sources[row.UserID] = row.ViaRole
+	}
+	return sources
+}
+
+// roleHolders pages through the guild's members and returns the humans holding any of
+// the roles.
+func (s *StandupService) roleHolders(guildID string, roleIDs []string) (map[string]bool, error) {
+	holders := make(map[string]bool)
+	after := ""
+	for {
+		members, err := s.Session.GuildMembers(guildID, after, guildMembersPage)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't list server members: %w", err)
+		}
+		for _, member := range members {
+			if member.User == nil || member.User.Bot {
+				continue
+			}
+			if holdsAnyRole(member.Roles, roleIDs) {
+				holders[member.User.ID] = true
+			}
+		}
+		if len(members) < guildMembersPage {
+			return holders, nil
+		}
+		after = members[len(members)-1].User.ID
+	}
+}
+
+func holdsAnyRole(roles, wanted []string) bool {
+	for _, role := range roles {
+		for _, id := range wanted {
+			if role == id {
+				return true
+			}
+		}
+	}
+	return false
+}
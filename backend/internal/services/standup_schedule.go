@@ -0,0 +1,550 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/store"
+)
+
+const (
+	defaultStandupTime = "09:00"
+	defaultActiveDays  = "Monday,Tuesday,Wednesday,Thursday,Friday"
+
+	// DefaultCatchUpWindow is how late a missed prompt may still be delivered.
+	DefaultCatchUpWindow = 2 * time.Hour
+)
+
+// earliestZone is the first timezone to reach each new date.
+var earliestZone = time.FixedZone("UTC+14", 14*60*60)
+
+func parseStandupTime(timeStr string) (int, int, error) {
+	if timeStr == "" {
+		timeStr = defaultStandupTime
+	}
+
+	var hour, minute int
+	if _, err := fmt.Sscanf(timeStr, "%d:%d", &hour, &minute); err != nil {
+		return 0, 0, err
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("time %q out of range", timeStr)
+	}
+	return hour, minute, nil
+}
+
+// nextFireTime returns the first scheduled instant strictly after `after`, evaluated
+// in loc and skipping local dates listed in holidays. A zero time means the standup has
+// no valid upcoming occurrence.
+func nextFireTime(standup models.Standup, loc *time.Location, after time.Time,
+	holidays map[string]bool) time.Time {
+
+	return nextOccurrence(standup, standup.Time, loc, after, holidays)
+}
+
+// nextOccurrence is nextFireTime for an arbitrary clock time on the standup's active
+// days, e.g. its submission cutoff. Days the standup is paused are skipped like holidays.
+func nextOccurrence(standup models.Standup, clock string, loc *time.Location, after time.Time,
+	holidays map[string]bool) time.Time {
+
+	if standup.IsPaused() && standup.ResumeOn == "" {
+		return time.Time{}
+	}
+
+	hour, minute, err := parseStandupTime(clock)
+	if err != nil {
+		log.Printf("Invalid time format for standup %s: %s", standup.Name, clock)
+		return time.Time{}
+	}
+
+	runsOn, err := cadenceMatcher(standup)
+	if err != nil {
+		log.Printf("Invalid cadence for standup %s: %v", standup.Name, err)
+		return time.Time{}
+	}
+	isWeekly := standup.ScheduleType == "" || standup.ScheduleType == models.ScheduleWeekly
+
+	// A week is enough to find the next active day of a weekly standup, but monthly and
+	// multi-week cadences, or a long run of holidays, can push it much further out.
+	local := after.In(loc)
+	for i := 0; i <= 366; i++ {
+		day := local.AddDate(0, 0, i)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+		if !candidate.After(after) {
+			continue
+		}
+		date := candidate.Format("2006-01-02")
+		if holidays[date] || standup.PausedOn(date) {
+			continue
+		}
+		if runsOn(candidate) {
+			return candidate.UTC()
+		}
+		if i >= 7 && isWeekly && len(holidays) == 0 && !standup.IsPaused() {
+			break
+		}
+	}
+	return time.Time{}
+}
+
+// cadenceMatcher returns a predicate telling whether the standup runs on a given local day.
+func cadenceMatcher(standup models.Standup) (func(time.Time) bool, error) {
+	activeDays := standup.Days
+	if activeDays == "" {
+		activeDays = defaultActiveDays
+	}
+	onActiveDay := func(day time.Time) bool {
+		return strings.Contains(activeDays, day.Weekday().String())
+	}
+
+	switch standup.ScheduleType {
+	case "", models.ScheduleWeekly:
+		return onActiveDay, nil
+
+	case models.ScheduleInterval:
+		anchor, err := time.Parse("2006-01-02", standup.AnchorDate)
+		if err != nil {
+			return nil, errors.New("anchor date must use YYYY-MM-DD")
+		}
+		if standup.IntervalWeeks < 1 {
+			return nil, errors.New("interval must be at least one week")
+		}
+		anchorWeek := weekStart(anchor)
+		return func(day time.Time) bool {
+			if !onActiveDay(day) {
+				return false
+			}
+			date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+			weeks := int(weekStart(date).Sub(anchorWeek).Hours()/24) / 7
+			return weeks%standup.IntervalWeeks == 0
+		}, nil
+
+	case models.ScheduleMonthly:
+		if standup.MonthWeek != -1 && (standup.MonthWeek < 1 || standup.MonthWeek > 4) {
+			return nil, errors.New("week of the month must be 1-4, or -1 for the last one")
+		}
+		return func(day time.Time) bool {
+			if !onActiveDay(day) {
+				return false
+			}
+			if standup.MonthWeek == -1 {
+				return day.AddDate(0, 0, 7).Month() != day.Month()
+			}
+			return (day.Day()-1)/7+1 == standup.MonthWeek
+		}, nil
+
+	case models.ScheduleCron:
+		spec, err := parseCron(standup.CronExpr)
+		if err != nil {
+			return nil, err
+		}
+		return spec.matchesDay, nil
+	}
+
+	return nil, fmt.Errorf("unknown schedule type %q", standup.ScheduleType)
+}
+
+// weekStart returns the Monday (at midnight UTC) of the week containing date.
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// syncCronTime keeps Time in step with a cron standup's hour and minute, so everything
+// that only reads Time (reminders, /standup-info, welcome DMs) stays correct.
+func syncCronTime(standup *models.Standup) {
+	if standup.ScheduleType != models.ScheduleCron {
+		return
+	}
+	if spec, err := parseCron(standup.CronExpr); err == nil {
+		standup.Time = spec.clock()
+	}
+}
+
+// ValidationError reports settings the caller got wrong, as opposed to a failure to
+// store them, so the API can answer 400 rather than 500.
+type ValidationError struct {
+	Err error
+}
+
+func (e ValidationError) Error() string { return e.Err.Error() }
+
+func (e ValidationError) Unwrap() error { return e.Err }
+
+// IsValidationError reports whether err was caused by invalid input.
+func IsValidationError(err error) bool {
+	var verr ValidationError
+	return errors.As(err, &verr)
+}
+
+func validateScheduleRules(standup models.Standup) error {
+	if _, err := cadenceMatcher(standup); err != nil {
+		return err
+	}
+	if standup.Timezone != "" {
+		if _, err := time.LoadLocation(standup.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", standup.Timezone)
+		}
+	}
+	if standup.CutoffTime != "" {
+		if _, _, err := parseStandupTime(standup.CutoffTime); err != nil {
+			return errors.New("cutoff time must use HH:MM in 24h format")
+		}
+	}
+	switch standup.ReportMode {
+	case "", models.ReportModeIndividual:
+	case models.ReportModeDigest:
+		if _, _, err := parseStandupTime(standup.DigestTime); err != nil || standup.DigestTime == "" {
+			return errors.New("digest mode needs a digest time in HH:MM 24h format")
+		}
+	default:
+		return fmt.Errorf("unknown report mode %q", standup.ReportMode)
+	}
+	for _, minutes := range standup.ReminderOffsets {
+		if minutes <= 0 || minutes >= 24*60 {
+			return errors.New("reminder delays must be between 1 minute and 24 hours")
+		}
+	}
+	if standup.MaxReminders < 0 {
+		return errors.New("max reminders cannot be negative")
+	}
+	return nil
+}
+
+// nextReminderAt returns when the participant's next nudge is due for the prompt recorded
+// in schedule.LastFiredAt, or a zero time once the standup's reminder rules are used up.
+// When MaxReminders exceeds the configured delays, the spacing of the last two repeats.
+func nextReminderAt(standup models.Standup, schedule models.StandupSchedule) time.Time {
+	offsets := standup.ReminderOffsets
+	if schedule.LastFiredAt == nil || len(offsets) == 0 || standup.IsPaused() {
+		return time.Time{}
+	}
+
+	limit := standup.MaxReminders
+	if limit <= 0 {
+		limit = len(offsets)
+	}
+	n := schedule.RemindersSent
+	if n >= limit {
+		return time.Time{}
+	}
+
+	var minutes int64
+	if n < len(offsets) {
+		minutes = offsets[n]
+	} else {
+		last := offsets[len(offsets)-1]
+		gap := last
+		if len(offsets) > 1 {
+			gap = last - offsets[len(offsets)-2]
+		}
+		minutes = last + int64(n-len(offsets)+1)*gap
+	}
+
+	remindAt := schedule.LastFiredAt.Add(time.Duration(minutes) * time.Minute).UTC()
+	if !schedule.NextFireAt.IsZero() && !remindAt.Before(schedule.NextFireAt) {
+		return time.Time{}
+	}
+	return remindAt
+}
+
+// standupLocation is the timezone that standup-wide events such as the cutoff follow:
+// the standup's own when it is anchored to one, otherwise the manager's.
+func (s *StandupService) standupLocation(standup models.Standup) *time.Location {
+	if standup.Timezone != "" {
+		return loadLocation(make(map[string]*time.Location), standup.Timezone, standup.ManagerID)
+	}
+
+	var manager models.UserProfile
+	s.DB.Where("user_id = ?", standup.ManagerID).First(&manager)
+	return loadLocation(make(map[string]*time.Location), manager.Timezone, standup.ManagerID)
+}
+
+// scheduleLocation is the clock a participant's prompt follows. An anchored standup fires
+// at the same instant for everyone; otherwise Time is read in each participant's own zone.
+func scheduleLocation(cache map[string]*time.Location, standup models.Standup,
+	user models.UserProfile) *time.Location {
+
+	if standup.Timezone != "" {
+		return loadLocation(cache, standup.Timezone, user.UserID)
+	}
+	return loadLocation(cache, user.Timezone, user.UserID)
+}
+
+// standupEntries returns the standup-wide events (not tied to one participant) that
+// should be queued, using a zero FireAt for the ones that are switched off.
+func (s *StandupService) standupEntries(standup models.Standup, after time.Time,
+	holidays map[string]bool) []scheduleEntry {
+
+	deadline := scheduleEntry{Kind: entryDeadline, StandupID: standup.ID}
+	if standup.CutoffTime != "" {
+		deadline.FireAt = nextOccurrence(standup, standup.CutoffTime, s.standupLocation(standup), after, holidays)
+	}
+
+	digest := scheduleEntry{Kind: entryDigest, StandupID: standup.ID}
+	if standup.IsDigest() && standup.DigestTime != "" {
+		digest.FireAt = nextOccurrence(standup, standup.DigestTime, s.standupLocation(standup), after, holidays)
+	}
+
+	resume := scheduleEntry{Kind: entryResume, StandupID: standup.ID, FireAt: resumeAt(standup)}
+
+	return []scheduleEntry{deadline, digest, resume}
+}
+
+// resumeAt is when a paused standup with a resume date comes back on: the first moment
+// that date begins anywhere, so nobody is prompted before hearing it resumed.
+func resumeAt(standup models.Standup) time.Time {
+	if !standup.IsPaused() || standup.ResumeOn == "" {
+		return time.Time{}
+	}
+	day, err := time.Parse("2006-01-02", standup.ResumeOn)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, earliestZone).UTC()
+}
+
+func loadLocation(cache map[string]*time.Location, tz, userID string) *time.Location {
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	if loc, exists := cache[tz]; exists {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		log.Printf("⚠️ Warning: Unknown timezone '%s' for user %s. Falling back to UTC.", tz, userID)
+		loc = time.UTC
+	}
+	cache[tz] = loc
+	return loc
+}
+
+func (s *StandupService) scheduler() *Scheduler {
+	s.schedulerOnce.Do(func() {
+		s.sched = NewScheduler()
+	})
+	return s.sched
+}
+
+// InvalidateStandup recomputes every participant's next fire time after the standup's
+// schedule or membership changed. Rows for people no longer in it are dropped.
+func (s *StandupService) InvalidateStandup(standupID uint) {
+	var standup models.Standup
+	if err := s.DB.Preload("Participants").First(&standup, standupID).Error; err != nil ||
+		len(standup.Participants) == 0 {
+		keys := s.deleteSchedules("standup_id = ?", standupID)
+		s.publishScheduleChanges(append(keys, scheduleKey(standupID, "")))
+		return
+	}
+
+	var memberIDs []string
+	for _, user := range standup.Participants {
+		memberIDs = append(memberIDs, user.UserID)
+	}
+
+	keys := s.deleteSchedules("standup_id = ? AND user_id NOT IN ?", standupID, memberIDs)
+	for _, user := range standup.Participants {
+		keys = append(keys, s.refreshSchedule(standup, user))
+	}
+	s.publishScheduleChanges(append(keys, scheduleKey(standupID, "")))
+}
+
+// InvalidateParticipant recomputes a single participant's row after they joined or left.
+func (s *StandupService) InvalidateParticipant(userID string, standupID uint) {
+	var user models.UserProfile
+	err := s.DB.Preload("Standups", "id = ?", standupID).Where("user_id = ?", userID).First(&user).Error
+	if err != nil || len(user.Standups) == 0 {
+		s.publishScheduleChanges(s.deleteSchedules("standup_id = ? AND user_id = ?", standupID, userID))
+		return
+	}
+
+	s.publishScheduleChanges([]string{s.refreshSchedule(user.Standups[0], user)})
+}
+
+// InvalidateUser recomputes all of a user's rows, e.g. after a timezone change. Standups
+// they manage are refreshed too, since standup-wide events follow the manager's clock.
+func (s *StandupService) InvalidateUser(userID string) {
+	var managedIDs []uint
+	s.DB.Model(&models.Standup{}).Where("manager_id = ?", userID).Pluck("id", &managedIDs)
+
+	var managedKeys []string
+	for _, id := range managedIDs {
+		managedKeys = append(managedKeys, scheduleKey(id, ""))
+	}
+
+	var user models.UserProfile
+	if err := s.DB.Preload("Standups").Where("user_id = ?", userID).First(&user).Error; err != nil ||
+		len(user.Standups) == 0 {
+		s.publishScheduleChanges(append(s.deleteSchedules("user_id = ?", userID), managedKeys...))
+		return
+	}
+
+	var standupIDs []uint
+	for _, st := range user.Standups {
+		standupIDs = append(standupIDs, st.ID)
+	}
+
+	keys := s.deleteSchedules("user_id = ? AND standup_id NOT IN ?", userID, standupIDs)
+	for _, st := range user.Standups {
+		keys = append(keys, s.refreshSchedule(st, user))
+	}
+	s.publishScheduleChanges(append(keys, managedKeys...))
+}
+
+func (s *StandupService) refreshSchedule(standup models.Standup, user models.UserProfile) string {
+	loc := scheduleLocation(make(map[string]*time.Location), standup, user)
+	currentMinute := time.Now().UTC().Truncate(time.Minute).Add(-time.Nanosecond)
+
+	var schedule models.StandupSchedule
+	s.DB.Where(models.StandupSchedule{StandupID: standup.ID, UserID: user.UserID}).FirstOrInit(&schedule)
+
+	personal := withOverride(standup, s.ParticipantOverride(standup.ID, user.UserID))
+	schedule.NextFireAt = nextFireTime(personal, loc, currentMinute, s.holidayDates(standup.ID))
+	if err := s.DB.Save(&schedule).Error; err != nil {
+		log.Printf("Error saving schedule for %s in standup %d: %v", user.UserID, standup.ID, err)
+	}
+	return scheduleKey(standup.ID, user.UserID)
+}
+
+func (s *StandupService) deleteSchedules(query string, args ...interface{}) []string {
+	var schedules []models.StandupSchedule
+	s.DB.Where(query, args...).Find(&schedules)
+
+	var keys []string
+	for _, schedule := range schedules {
+		keys = append(keys, scheduleKey(schedule.StandupID, schedule.UserID))
+		s.DB.Delete(&schedule)
+	}
+	return keys
+}
+
+func (s *StandupService) publishScheduleChanges(keys []string) {
+	if s.Redis != nil {
+		store.PublishScheduleChanges(s.Redis, keys)
+		return
+	}
+	s.applyScheduleChanges(keys)
+}
+
+// applyScheduleChanges reloads the given rows into the queue. Replicas that are not
+// currently running the scheduler have nothing loaded and ignore the message.
+func (s *StandupService) applyScheduleChanges(keys []string) {
+	if !s.scheduler().Loaded() {
+		return
+	}
+
+	currentMinute := time.Now().UTC().Truncate(time.Minute).Add(-time.Nanosecond)
+
+	for _, key := range keys {
+		idStr, userID, _ := strings.Cut(key, ":")
+		var standupID uint
+		if _, err := fmt.Sscanf(idStr, "%d", &standupID); err != nil {
+			continue
+		}
+
+		var standup models.Standup
+		standupErr := s.DB.First(&standup, standupID).Error
+
+		if userID == "" {
+			// A deleted standup stays zero-valued, which switches all of its events off.
+			standup.ID = standupID
+			for _, entry := range s.standupEntries(standup, currentMinute, s.holidayDates(standupID)) {
+				s.scheduler().Set(entry.Kind, entry.StandupID, "", entry.FireAt)
+			}
+			continue
+		}
+
+		var schedule models.StandupSchedule
+		if standupErr != nil ||
+			s.DB.Where("standup_id = ? AND user_id = ?", standupID, userID).First(&schedule).Error != nil {
+			s.scheduler().Remove(entryPrompt, standupID, userID)
+			s.scheduler().Remove(entryReminder, standupID, userID)
+			continue
+		}
+		s.scheduler().Set(entryPrompt, standupID, userID, schedule.NextFireAt)
+		s.scheduler().Set(entryReminder, standupID, userID, nextReminderAt(standup, schedule))
+	}
+}
+
+// FormatLocalTime describes when a standup's trigger time falls for a user. Standups
+// anchored to a timezone fire at one instant for everyone, so the user also sees what
+// that is on their own clock.
+func FormatLocalTime(dbTimeStr string, standupTZ string, userTZ string) string {
+	if standupTZ != "" {
+		local, ok := localEquivalent(dbTimeStr, standupTZ, userTZ)
+		if !ok || userTZ == standupTZ {
+			return fmt.Sprintf("**%s** (%s)", dbTimeStr, standupTZ)
+		}
+		if userTZ == "" {
+			return fmt.Sprintf("**%s** (%s), **%s** UTC. Run `/timezone` to see your local time",
+				dbTimeStr, standupTZ, local)
+		}
+		return fmt.Sprintf("**%s** (%s), **%s** your time (%s)", dbTimeStr, standupTZ, local, userTZ)
+	}
+
+	if userTZ == "" {
+		return fmt.Sprintf("**%s (Your Local Time)**\n> ⚠️ *Wait! You haven't set a timezone yet. Run `/timezone` so this triggers at your actual morning!*", dbTimeStr)
+	}
+
+	return fmt.Sprintf("**%s** (%s)", dbTimeStr, userTZ)
+}
+
+// localEquivalent converts an HH:MM time in standupTZ to the user's clock as of today,
+// noting when it lands on a different day. An empty userTZ means UTC.
+func localEquivalent(dbTimeStr string, standupTZ string, userTZ string) (string, bool) {
+	standupLoc, err := time.LoadLocation(standupTZ)
+	if err != nil {
+		return "", false
+	}
+	userLoc, err := time.LoadLocation(userTZ)
+	if err != nil {
+		userLoc = time.UTC
+	}
+	clock, err := time.Parse("15:04", dbTimeStr)
+	if err != nil {
+		return "", false
+	}
+
+	now := time.Now().In(standupLoc)
+	at := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, standupLoc)
+	local := at.In(userLoc)
+
+	label := local.Format("15:04")
+	standupDay := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	localDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	switch {
+	case localDay.After(standupDay):
+		label += " next day"
+	case localDay.Before(standupDay):
+		label += " previous day"
+	}
+	return label, true
+}
+
+// FormatCadence describes which days a standup runs on, as /standup-info and the
+// welcome DM show it.
+func FormatCadence(standup models.Standup) string {
+	days := strings.ReplaceAll(standup.Days, ",", ", ")
+	if days == "" {
+		days = "Monday-Friday"
+	}
+
+	switch standup.ScheduleType {
+	case models.ScheduleInterval:
+		return fmt.Sprintf("Every %d weeks on %s (counting from the week of %s)",
+			standup.IntervalWeeks, days, standup.AnchorDate)
+	case models.ScheduleMonthly:
+		ordinals := map[int]string{1: "First", 2: "Second", 3: "Third", 4: "Fourth", -1: "Last"}
+		return fmt.Sprintf("%s %s of every month", ordinals[standup.MonthWeek], days)
+	case models.ScheduleCron:
+		return fmt.Sprintf("Cron `%s`", standup.CronExpr)
+	}
+	return fmt.Sprintf("Every week on %s", days)
+}
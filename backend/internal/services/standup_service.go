@@ -4,18 +4,29 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/Gurkunwar/asyncflow/internal/models"
 	"github.com/bwmarrin/discordgo"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 type StandupService struct {
-	DB          *gorm.DB
-	Session     *discordgo.Session
-	TriggerFunc func(s *discordgo.Session, userID, guildID, channelID string, standupID uint)
+	DB            *gorm.DB
+	Session       *discordgo.Session
+	TriggerFunc   func(s *discordgo.Session, userID, guildID, channelID string, standupID uint)
+	ReminderFunc  func(s *discordgo.Session, userID string, standupID uint, reminderNumber int)
+	CatchUpWindow time.Duration
+	Redis         *redis.Client
+	InstanceID    string
+
+	isLeader      bool
+	sched         *Scheduler
+	schedulerOnce sync.Once
+	roleSyncMu    sync.Mutex
 }
 
 func (s *StandupService) CreateStandup(input models.Standup) (*models.Standup, error) {
@@ -28,6 +39,13 @@ func (s *StandupService) CreateStandup(input models.Standup) (*models.Standup, e
     if input.GuildID == "" {
         return nil, errors.New("guild ID cannot be empty")
     }
+    if err := validateScheduleRules(input); err != nil {
+        return nil, ValidationError{err}
+    }
+    if err := normalizeQuestionSpecs(&input); err != nil {
+        return nil, ValidationError{err}
+    }
+    syncCronTime(&input)
 
     if err := s.DB.FirstOrCreate(&models.Guild{}, models.Guild{GuildID: input.GuildID}).Error; err != nil {
         return nil, fmt.Errorf("failed to register guild in database: %v", err)
@@ -41,7 +59,20 @@ func (s *StandupService) CreateStandup(input models.Standup) (*models.Standup, e
 }
 
 func (s *StandupService) UpdateStandup(standup models.Standup) error {
-    return s.DB.Save(&standup).Error
+    if err := validateScheduleRules(standup); err != nil {
+        return ValidationError{err}
+    }
+    if err := normalizeQuestionSpecs(&standup); err != nil {
+        return ValidationError{err}
+    }
+    syncCronTime(&standup)
+
+    if err := s.DB.Save(&standup).Error; err != nil {
+        return err
+    }
+
+    s.InvalidateStandup(standup.ID)
+    return nil
 }
 
 func (s *StandupService) DeleteStandup(standupID uint) error {
@@ -59,17 +90,36 @@ func (s *StandupService) DeleteStandup(standupID uint) error {
     }
 
     s.DB.Model(&standup).Association("Participants").Clear()
-    return s.DB.Unscoped().Delete(&standup).Error
+    s.DB.Model(&standup).Association("HolidayCalendars").Clear()
+    s.DB.Where("standup_id = ?", standup.ID).Delete(&models.StandupMember{})
+    if err := s.DB.Unscoped().Delete(&standup).Error; err != nil {
+        return err
+    }
+
+    s.InvalidateStandup(standup.ID)
+    return nil
 }
 
+// GetUserManagedStandups returns the standups the user manages, co-manages or observes.
 func (s *StandupService) GetUserManagedStandups(managerID string) ([]models.Standup, error) {
 	var standups []models.Standup
-	err := s.DB.Where("manager_id = ?", managerID).Find(&standups).Error
+	err := s.DB.Where(s.AccessibleStandups(managerID, models.AccessView)).Find(&standups).Error
 
 	return standups, err
 }
 
-func (s *StandupService) AddMemberToStandup(userID string, standupID uint) error {
+// AddMemberToStandup adds the user to the standup, optionally with their own trigger time
+// and days (leave override empty to use the standup's), and DMs them their schedule.
+// override.ViaRole records whether role syncing added them; adding someone by hand makes
+// them a manual member even if a role brought them in first.
+func (s *StandupService) AddMemberToStandup(userID string, standupID uint,
+    override models.StandupParticipant) error {
+
+    override, err := normalizeOverride(override)
+    if err != nil {
+        return err
+    }
+
     var user models.UserProfile
     s.DB.Unscoped().Where("user_id = ?", userID).FirstOrCreate(&user, models.UserProfile{UserID: userID})
 
@@ -82,15 +132,43 @@ func (s *StandupService) AddMemberToStandup(userID string, standupID uint) error
         return err
     }
 
-    err := s.DB.Model(&user).Association("Standups").Append(&standup)
+    err = s.DB.Model(&user).Association("Standups").Append(&standup)
     if err != nil {
         return err
     }
 
+    s.DB.Model(&models.StandupParticipant{}).
+        Where("standup_id = ? AND user_profile_id = ?", standup.ID, user.ID).
+        Update("via_role", override.ViaRole)
+
+    if override.Time != "" || override.Days != "" {
+        s.DB.Model(&models.StandupParticipant{}).
+            Where("standup_id = ? AND user_profile_id = ?", standup.ID, user.ID).
+            Updates(map[string]interface{}{"time": override.Time, "days": override.Days})
+    } else {
+        override = s.ParticipantOverride(standup.ID, userID)
+    }
+
+    s.InvalidateParticipant(userID, standup.ID)
+
     if dmChannel, err := s.Session.UserChannelCreate(userID); err == nil {
+        personal := withOverride(standup, override)
+        if personal.Time == "" {
+            personal.Time = defaultStandupTime
+        }
+        timezone := user.Timezone
+        if timezone == "" {
+            timezone = "your local time, run `/timezone` to set it"
+        }
+        when := fmt.Sprintf("**%s** (%s)", personal.Time, timezone)
+        if standup.Timezone != "" {
+            when = FormatLocalTime(personal.Time, standup.Timezone, user.Timezone)
+        }
+
         welcomeMsg := fmt.Sprintf("👋 **You've been added to the '%s' Standup!**\n\n" +
+		"⏰ Scheduled for: %s\n🗓️ %s\n" +
 		"You can now submit your daily reports for this team.\nRun `/start` here or in the server to begin.", 
-		standup.Name)
+		standup.Name, when, FormatCadence(personal))
         s.Session.ChannelMessageSend(dmChannel.ID, welcomeMsg)
     }
 
@@ -113,6 +191,8 @@ func (s *StandupService) RemoveMemberFromStandup(userID string, standupID uint)
         return err
     }
 
+    s.InvalidateParticipant(userID, standup.ID)
+
     if dmChannel, err := s.Session.UserChannelCreate(userID); err == nil {
         goodbyeMsg := fmt.Sprintf("ℹ️ You have been removed from the **%s** standup team.", standup.Name)
         s.Session.ChannelMessageSend(dmChannel.ID, goodbyeMsg)
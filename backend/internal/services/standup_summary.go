@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (s *StandupService) fireDeadline(standup models.Standup, dueAt, now time.Time) {
+	loc := s.standupLocation(standup)
+	s.scheduler().Set(entryDeadline, standup.ID, "", nextOccurrence(standup, standup.CutoffTime, loc, now,
+		s.holidayDates(standup.ID)))
+
+	if now.Sub(dueAt) > s.catchUpWindow() || standup.ReportChannelID == "" {
+		return
+	}
+
+	summary := models.StandupSummary{
+		StandupID: standup.ID,
+		Date:      dueAt.In(loc).Format("2006-01-02"),
+		ChannelID: standup.ReportChannelID,
+	}
+	if !s.claimDate(&summary) {
+		return
+	}
+
+	embed, err := s.buildMissingReportsEmbed(standup, dueAt, now)
+	if err != nil {
+		log.Printf("Error building cutoff summary for standup %d: %v", standup.ID, err)
+		s.retryStandupEvent(entryDeadline, standup.ID, &summary, dueAt)
+		return
+	}
+
+	msg, err := s.Session.ChannelMessageSendEmbed(standup.ReportChannelID, embed)
+	if err != nil {
+		log.Printf("Warning: Failed to post cutoff summary to channel %s: %v", standup.ReportChannelID, err)
+		s.retryStandupEvent(entryDeadline, standup.ID, &summary, dueAt)
+		return
+	}
+
+	s.DB.Model(&summary).Update("message_id", msg.ID)
+}
+
+// buildMissingReportsEmbed sorts participants by what they did on the day of the cutoff
+// at dueAt, taken as each person's own local date then, matching the date
+// finalizeStandup records. A cutoff caught up late still reports on its own day.
+func (s *StandupService) buildMissingReportsEmbed(standup models.Standup,
+	dueAt, now time.Time) (*discordgo.MessageEmbed, error) {
+
+	var participants []models.UserProfile
+	if err := s.DB.Model(&standup).Association("Participants").Find(&participants); err != nil {
+		return nil, err
+	}
+
+	tzCache := make(map[string]*time.Location)
+	var submitted, skipped, away, missing []string
+
+	for _, user := range participants {
+		localToday := dueAt.In(loadLocation(tzCache, user.Timezone, user.UserID)).Format("2006-01-02")
+		mention := fmt.Sprintf("<@%s>", user.UserID)
+
+		var history models.StandupHistory
+		err := s.DB.Where("user_id = ? AND standup_id = ? AND date = ?",
+			user.UserID, standup.ID, localToday).First(&history).Error
+
+		switch {
+		case err != nil:
+			missing = append(missing, mention)
+		case len(history.Answers) == 1 && history.Answers[0] == models.SkippedAnswer:
+			skipped = append(skipped, mention)
+		case len(history.Answers) == 1 && history.Answers[0] == models.OutOfOfficeAnswer:
+			away = append(away, mention)
+		default:
+			submitted = append(submitted, mention)
+		}
+	}
+
+	color := 0x57F287
+	if len(missing) > 0 {
+		color = 0xED4245
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("⏳ %s: Submission Deadline Reached", standup.Name),
+		Description: fmt.Sprintf("Here's who checked in before the **%s** cutoff.", standup.CutoffTime),
+		Color:       color,
+		Fields: []*discordgo.MessageEmbedField{
+			summaryField("✅ Submitted", submitted),
+			summaryField("⏭️ Skipped Today", skipped),
+			summaryField("🌴 Out of Office", away),
+			summaryField("❌ No Response", missing),
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}, nil
+}
+
+func summaryField(title string, mentions []string) *discordgo.MessageEmbedField {
+	value := strings.Join(mentions, " ")
+	if len(mentions) == 0 {
+		value = "*Nobody*"
+	} else if len(value) > 1000 {
+		value = fmt.Sprintf("*%d members (List too long to display)*", len(mentions))
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("%s (%d)", title, len(mentions)),
+		Value: value,
+	}
+}
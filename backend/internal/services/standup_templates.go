@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/lib/pq"
+)
+
+// DefaultTemplateKey is the built-in template standups start from when none is picked.
+const DefaultTemplateKey = "daily-scrum"
+
+// BuiltinTemplates are the templates every server and user is offered, in display order.
+func BuiltinTemplates() []models.StandupTemplate {
+	// Yesterday's "today" answer is where "yesterday" starts from.
+	planQuestion := 1
+	lowMoods := []string{"1", "2"}
+
+	builtins := []models.StandupTemplate{
+		{
+			Key:         DefaultTemplateKey,
+			Name:        "Daily Scrum",
+			Description: "The classic three questions every weekday morning.",
+			Questions: pq.StringArray{
+				"What did you accomplish yesterday?",
+				"What will you do today?",
+				"Are you stuck anywhere? (Blockers)",
+			},
+			QuestionSpecs: []models.QuestionSpec{
+				{Type: models.QuestionText, CarryFrom: &planQuestion},
+				{Type: models.QuestionText},
+				{Type: models.QuestionText},
+			},
+			Time: "09:00",
+			Days: defaultActiveDays,
+		},
+		{
+			Key:         "weekly-retro",
+			Name:        "Weekly Retro",
+			Description: "A Friday afternoon look back at the week, posted as one digest.",
+			Questions: pq.StringArray{
+				"What went well this week?",
+				"What didn't go so well?",
+				"What should we try next week?",
+			},
+			QuestionSpecs: []models.QuestionSpec{
+				{Type: models.QuestionText},
+				{Type: models.QuestionText},
+				{Type: models.QuestionText, Optional: true},
+			},
+			Time:         "15:00",
+			Days:         "Friday",
+			ScheduleType: models.ScheduleWeekly,
+			ReportMode:   models.ReportModeDigest,
+			DigestTime:   "17:00",
+		},
+		{
+			Key:         "mood-check",
+			Name:        "Mood Check",
+			Description: "A quick 1-5 pulse of how the team is feeling, with a follow-up on rough days.",
+			Questions: pq.StringArray{
+				"How are you feeling today? (1 = rough, 5 = great)",
+				"Anything weighing on you that the team could help with?",
+			},
+			QuestionSpecs: []models.QuestionSpec{
+				{Type: models.QuestionScale},
+				{Type: models.QuestionText, Optional: true,
+					ShowIf: &models.QuestionCondition{Question: 0, Answers: lowMoods}},
+			},
+			Time:       "10:00",
+			Days:       defaultActiveDays,
+			ReportMode: models.ReportModeDigest,
+			DigestTime: "12:00",
+		},
+	}
+	for i := range builtins {
+		builtins[i].Scope = models.TemplateBuiltin
+	}
+	return builtins
+}
+
+// GetTemplates lists the templates the user can start a standup from in the guild:
+// the built-in ones, the guild's, then their own.
+func (s *StandupService) GetTemplates(guildID, userID string) ([]models.StandupTemplate, error) {
+	var stored []models.StandupTemplate
+	err := s.DB.Where("(scope = ? AND guild_id = ?) OR (scope = ? AND owner_id = ?)",
+		models.TemplateGuild, guildID, models.TemplatePersonal, userID).
+		Order("scope asc, name asc").Find(&stored).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(BuiltinTemplates(), stored...), nil
+}
+
+// FindTemplate resolves a template reference, as given by Ref or typed by hand as a
+// template's name, among the templates the user can see in the guild.
+func (s *StandupService) FindTemplate(guildID, userID, ref string) (*models.StandupTemplate, error) {
+	ref = strings.TrimSpace(ref)
+	templates, err := s.GetTemplates(guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tmpl := range templates {
+		if tmpl.Ref() == ref {
+			return &tmpl, nil
+		}
+	}
+	// Personal templates are listed last but win a name clash, then the guild's.
+	for i := len(templates) - 1; i >= 0; i-- {
+		if strings.EqualFold(templates[i].Name, ref) {
+			return &templates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no template called %q", ref)
+}
+
+// SaveTemplate validates and stores a guild or personal template. A template with the
+// same name in the same place is replaced.
+func (s *StandupService) SaveTemplate(tmpl models.StandupTemplate) (*models.StandupTemplate, error) {
+	tmpl.Name = strings.TrimSpace(tmpl.Name)
+	if tmpl.Name == "" {
+		return nil, errors.New("template name cannot be empty")
+	}
+	switch tmpl.Scope {
+	case models.TemplateGuild:
+		if tmpl.GuildID == "" {
+			return nil, errors.New("guild ID cannot be empty")
+		}
+		tmpl.OwnerID = ""
+	case models.TemplatePersonal:
+		if tmpl.OwnerID == "" {
+			return nil, errors.New("owner cannot be empty")
+		}
+		tmpl.GuildID = ""
+	default:
+		return nil, fmt.Errorf("scope must be %s or %s", models.TemplateGuild, models.TemplatePersonal)
+	}
+	for _, builtin := range BuiltinTemplates() {
+		if strings.EqualFold(builtin.Name, tmpl.Name) {
+			return nil, fmt.Errorf("%q is the name of a built-in template", builtin.Name)
+		}
+	}
+	if err := normalizeTemplate(&tmpl); err != nil {
+		return nil, err
+	}
+
+	var existing models.StandupTemplate
+	err := s.DB.Where("scope = ? AND guild_id = ? AND owner_id = ? AND LOWER(name) = LOWER(?)",
+		tmpl.Scope, tmpl.GuildID, tmpl.OwnerID, tmpl.Name).First(&existing).Error
+	if err == nil && existing.ID != tmpl.ID {
+		if tmpl.ID != 0 {
+			return nil, fmt.Errorf("there is already a template called %q", existing.Name)
+		}
+		tmpl.ID = existing.ID
+		tmpl.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.DB.Save(&tmpl).Error; err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// DeleteTemplate removes a stored template. Standups made from it keep their settings.
+func (s *StandupService) DeleteTemplate(templateID uint) error {
+	result := s.DB.Delete(&models.StandupTemplate{}, templateID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("template not found")
+	}
+	return nil
+}
+
+// CanEditTemplate reports whether the user may change or delete the template. Personal
+// templates belong to their owner; guild ones to the server's admins and to whoever
+// manages a standup there, like the guild's holiday calendars.
+func (s *StandupService) CanEditTemplate(tmpl models.StandupTemplate, userID string, admin bool) bool {
+	switch tmpl.Scope {
+	case models.TemplatePersonal:
+		return tmpl.OwnerID == userID
+	case models.TemplateGuild:
+		return admin || s.ManagesGuild(userID, tmpl.GuildID)
+	}
+	return false
+}
+
+// ManagesGuild reports whether the user manages or co-manages at least one standup in
+// the guild.
+func (s *StandupService) ManagesGuild(userID, guildID string) bool {
+	var count int64
+	s.DB.Model(&models.Standup{}).Where("guild_id = ?", guildID).
+		Where(s.AccessibleStandups(userID, models.AccessManage)).Count(&count)
+	return count > 0
+}
+
+// TemplateFromStandup captures a standup's questions, schedule, days and report mode so
+// they can be saved as a template.
+func TemplateFromStandup(standup models.Standup) models.StandupTemplate {
+	tmpl := models.StandupTemplate{
+		Questions:     append(pq.StringArray{}, standup.Questions...),
+		Time:          standup.Time,
+		Days:          standup.Days,
+		ScheduleType:  standup.ScheduleType,
+		IntervalWeeks: standup.IntervalWeeks,
+		MonthWeek:     standup.MonthWeek,
+		CronExpr:      standup.CronExpr,
+		ReportMode:    standup.ReportMode,
+		DigestTime:    standup.DigestTime,
+	}
+	for i := range standup.Questions {
+		spec := standup.QuestionSpec(i)
+		spec.ID = ""
+		tmpl.QuestionSpecs = append(tmpl.QuestionSpecs, spec)
+	}
+	return tmpl
+}
+
+// normalizeTemplate checks the template would make a valid standup, with the same rules
+// CreateStandup applies.
+func normalizeTemplate(tmpl *models.StandupTemplate) error {
+	if len(tmpl.Questions) == 0 {
+		return errors.New("at least one question is required")
+	}
+	if tmpl.Time != "" {
+		hour, minute, err := parseStandupTime(tmpl.Time)
+		if err != nil {
+			return errors.New("time must use HH:MM in 24h format")
+		}
+		tmpl.Time = fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+	if tmpl.Days != "" {
+		days, err := normalizeDays(tmpl.Days)
+		if err != nil {
+			return err
+		}
+		tmpl.Days = days
+	}
+
+	var standup models.Standup
+	tmpl.Apply(&standup)
+	if standup.ScheduleType == models.ScheduleInterval {
+		// Interval standups count from the day they are created.
+		standup.AnchorDate = time.Now().UTC().Format("2006-01-02")
+	}
+	if err := validateScheduleRules(standup); err != nil {
+		return err
+	}
+	if err := normalizeQuestionSpecs(&standup); err != nil {
+		return err
+	}
+
+	tmpl.QuestionSpecs = standup.QuestionSpecs
+	for i := range tmpl.QuestionSpecs {
+		tmpl.QuestionSpecs[i].ID = ""
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxThreadNameLength  = 100
+	maxMessageLength     = 2000
+	threadArchiveMinutes = 1440
+)
+
+// threadMu serialises opening a day's thread so two submissions arriving together don't
+// both start one.
+var threadMu sync.Mutex
+
+// ReportChannel returns where a participant's report for their local date is posted: the
+// day's thread when the standup uses one, opening it on the first report, or the report
+// channel itself.
+func (s *StandupService) ReportChannel(standup models.Standup, userID, date string) string {
+	if !standup.UsesDailyThread() {
+		return standup.ReportChannelID
+	}
+
+	thread, err := s.dailyThread(standup, s.threadDateFor(standup, userID, date))
+	if err != nil {
+		log.Printf("Warning: Falling back to the report channel for standup %d: %v", standup.ID, err)
+		return standup.ReportChannelID
+	}
+	return thread.ThreadID
+}
+
+// threadDateFor is the thread a participant's report for their local date belongs in.
+func (s *StandupService) threadDateFor(standup models.Standup, userID, date string) string {
+	var user models.UserProfile
+	s.DB.Where("user_id = ?", userID).First(&user)
+	return s.threadDate(standup, s.standupLocation(standup), user, date)
+}
+
+// threadDate maps a participant's local date to the date of their prompt on the
+// standup's clock, loc, so everyone answering the same standup day shares one thread
+// even when they are on different sides of the date line.
+func (s *StandupService) threadDate(standup models.Standup, loc *time.Location, user models.UserProfile,
+	date string) string {
+
+	prompt, ok := s.promptFor(standup, user, date)
+	if !ok {
+		return date
+	}
+	return prompt.In(loc).Format("2006-01-02")
+}
+
+func (s *StandupService) dailyThread(standup models.Standup, date string) (*models.StandupThread, error) {
+	threadMu.Lock()
+	defer threadMu.Unlock()
+
+	var thread models.StandupThread
+	err := s.DB.Where("standup_id = ? AND date = ?", standup.ID, date).First(&thread).Error
+	if err == nil && thread.ThreadID != "" {
+		return &thread, nil
+	}
+
+	// A claim without a thread is either being opened right now or was left behind by a
+	// process that died mid-way; the latter is taken over once it is old enough.
+	thread = models.StandupThread{StandupID: standup.ID, Date: date, ChannelID: standup.ReportChannelID}
+	if !s.claimDate(&thread) && !s.takeOverStaleClaim(&thread, standup.ID, date, "thread_id = ''") {
+		return nil, errors.New("the day's thread is still being opened")
+	}
+
+	msg, err := s.Session.ChannelMessageSendComplex(thread.ChannelID, &discordgo.MessageSend{
+		Content:         s.threadSummary(standup, thread),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		s.releaseClaim(&thread)
+		return nil, fmt.Errorf("failed to post thread summary: %w", err)
+	}
+
+	channel, err := s.Session.MessageThreadStartComplex(thread.ChannelID, msg.ID, &discordgo.ThreadStart{
+		Name:                truncateText(fmt.Sprintf("%s – %s", standup.Name, date), maxThreadNameLength),
+		AutoArchiveDuration: threadArchiveMinutes,
+	})
+	if err != nil {
+		s.Session.ChannelMessageDelete(thread.ChannelID, msg.ID)
+		s.releaseClaim(&thread)
+		return nil, fmt.Errorf("failed to start thread: %w", err)
+	}
+
+	thread.SummaryMessageID = msg.ID
+	thread.ThreadID = channel.ID
+	if err := s.DB.Save(&thread).Error; err != nil {
+		return nil, err
+	}
+
+	// The summary links to the thread, which only exists now.
+	s.editThreadSummary(standup, thread)
+	return &thread, nil
+}
+
+// RefreshThreadSummary brings the summary of the day a participant's local date falls on
+// up to date after their submission, skip or retraction. Days without a thread have
+// nothing to refresh.
+func (s *StandupService) RefreshThreadSummary(standup models.Standup, userID, date string) {
+	if !standup.UsesDailyThread() {
+		return
+	}
+
+	var thread models.StandupThread
+	if err := s.DB.Where("standup_id = ? AND date = ?", standup.ID,
+		s.threadDateFor(standup, userID, date)).First(&thread).Error; err != nil ||
+		thread.SummaryMessageID == "" {
+		return
+	}
+	s.editThreadSummary(standup, thread)
+}
+
+func (s *StandupService) editThreadSummary(standup models.Standup, thread models.StandupThread) {
+	content := s.threadSummary(standup, thread)
+	if _, err := s.Session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:              thread.SummaryMessageID,
+		Channel:         thread.ChannelID,
+		Content:         &content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}); err != nil {
+		log.Printf("Warning: Failed to update thread summary %s: %v", thread.SummaryMessageID, err)
+	}
+}
+
+// threadSummary lists who has submitted, skipped, is away or is still missing for the
+// thread's date. Mentions are rendered without pinging anyone.
+func (s *StandupService) threadSummary(standup models.Standup, thread models.StandupThread) string {
+	var participants []models.UserProfile
+	s.DB.Model(&standup).Association("Participants").Find(&participants)
+
+	// A report's own date can be a day either side of the thread's, see threadDate.
+	var candidates []models.StandupHistory
+	if day, err := time.Parse("2006-01-02", thread.Date); err == nil {
+		s.DB.Where("standup_id = ? AND date >= ? AND date <= ?", standup.ID,
+			day.AddDate(0, 0, -1).Format("2006-01-02"), day.AddDate(0, 0, 1).Format("2006-01-02")).
+			Order("created_at asc").Find(&candidates)
+	}
+
+	var userIDs []string
+	for _, h := range candidates {
+		userIDs = append(userIDs, h.UserID)
+	}
+	var profiles []models.UserProfile
+	s.DB.Where("user_id IN ?", userIDs).Find(&profiles)
+	profileMap := make(map[string]models.UserProfile, len(profiles))
+	for _, p := range profiles {
+		profileMap[p.UserID] = p
+	}
+
+	loc := s.standupLocation(standup)
+	var histories []models.StandupHistory
+	for _, h := range candidates {
+		if s.threadDate(standup, loc, profileMap[h.UserID], h.Date) == thread.Date {
+			histories = append(histories, h)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var submitted, skipped, away, waiting []string
+	for _, h := range histories {
+		seen[h.UserID] = true
+		mention := fmt.Sprintf("<@%s>", h.UserID)
+		switch {
+		case len(h.Answers) == 1 && h.Answers[0] == models.SkippedAnswer:
+			skipped = append(skipped, mention)
+		case len(h.Answers) == 1 && h.Answers[0] == models.OutOfOfficeAnswer:
+			away = append(away, mention)
+		default:
+			submitted = append(submitted, mention)
+		}
+	}
+	for _, p := range participants {
+		if !seen[p.UserID] {
+			waiting = append(waiting, fmt.Sprintf("<@%s>", p.UserID))
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🧵 **%s standup – %s**\n", standup.Name, thread.Date)
+	fmt.Fprintf(&b, "✅ Submitted (%d/%d): %s\n", len(submitted), len(participants), mentionList(submitted))
+	if len(skipped) > 0 {
+		fmt.Fprintf(&b, "⏭️ Skipped: %s\n", mentionList(skipped))
+	}
+	if len(away) > 0 {
+		fmt.Fprintf(&b, "🌴 Out of office: %s\n", mentionList(away))
+	}
+	if len(waiting) > 0 {
+		fmt.Fprintf(&b, "⏳ Not in yet: %s\n", mentionList(waiting))
+	}
+	if thread.ThreadID != "" {
+		fmt.Fprintf(&b, "\n💬 Today's updates and replies are in <#%s>.", thread.ThreadID)
+	}
+	return truncateText(b.String(), maxMessageLength)
+}
+
+func mentionList(mentions []string) string {
+	if len(mentions) == 0 {
+		return "*nobody yet*"
+	}
+	return strings.Join(mentions, " ")
+}
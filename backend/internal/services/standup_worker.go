@@ -3,13 +3,28 @@ package services
 import (
 	"fmt"
 	"log"
-	"strings"
+	"os"
 	"time"
 
 	"github.com/Gurkunwar/asyncflow/internal/models"
+	"github.com/Gurkunwar/asyncflow/internal/store"
+)
+
+const (
+	schedulerLeaseKey = "standup_scheduler"
+	schedulerLeaseTTL = 90 * time.Second
 )
 
 func (s *StandupService) StartTimezoneWorker() {
+	if s.InstanceID == "" {
+		hostname, _ := os.Hostname()
+		s.InstanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	if s.Redis != nil {
+		store.SubscribeScheduleChanges(s.Redis, s.applyScheduleChanges)
+	}
+
 	ticker := time.NewTicker(1 * time.Minute)
 	go func() {
 		for range ticker.C {
@@ -20,87 +35,310 @@ func (s *StandupService) StartTimezoneWorker() {
 					}
 				}()
 
+				if !s.holdsSchedulerLease() {
+					return
+				}
+
 				s.CheckAndTriggerStandups()
 			}()
 		}
 	}()
 }
 
-func (s *StandupService) CheckAndTriggerStandups() {
-	var standups []models.Standup
+// StopTimezoneWorker hands the scheduler lease back so another replica can take over
+// on its next tick instead of waiting for the lease to expire.
+func (s *StandupService) StopTimezoneWorker() {
+	if s.Redis != nil && s.isLeader {
+		store.ReleaseLease(s.Redis, schedulerLeaseKey, s.InstanceID)
+	}
+}
+
+func (s *StandupService) holdsSchedulerLease() bool {
+	isLeader := s.Redis == nil ||
+		store.AcquireLease(s.Redis, schedulerLeaseKey, s.InstanceID, schedulerLeaseTTL)
+
+	if isLeader != s.isLeader {
+		if isLeader {
+			// Whoever held the lease before us kept advancing the persisted rows, so the
+			// queue is rebuilt from the database rather than from our stale memory.
+			if err := s.RebuildSchedule(); err != nil {
+				log.Printf("Error rebuilding standup schedule: %v", err)
+				return false
+			}
+			log.Printf("👑 %s is now running the standup scheduler (%d entries)",
+				s.InstanceID, s.scheduler().Len())
+		} else {
+			log.Printf("💤 %s lost the standup scheduler lease", s.InstanceID)
+			s.scheduler().Reset()
+		}
+		s.isLeader = isLeader
+	}
+	return isLeader
+}
 
+// RebuildSchedule loads every participant's persisted next fire time into the queue,
+// recomputing rows that are missing or older than the standup or profile they describe.
+func (s *StandupService) RebuildSchedule() error {
+	var standups []models.Standup
 	if err := s.DB.Preload("Participants").Find(&standups).Error; err != nil {
-		log.Println("Error fetching standups:", err)
-		return
+		return err
+	}
+
+	var schedules []models.StandupSchedule
+	if err := s.DB.Find(&schedules).Error; err != nil {
+		return err
 	}
 
+	scheduleMap := make(map[string]*models.StandupSchedule, len(schedules))
+	for i := range schedules {
+		scheduleMap[scheduleKey(schedules[i].StandupID, schedules[i].UserID)] = &schedules[i]
+	}
+
+	now := time.Now().UTC()
+	currentMinute := now.Truncate(time.Minute).Add(-time.Nanosecond)
 	tzCache := make(map[string]*time.Location)
+	var entries []scheduleEntry
+
+	holidaysByStandup, err := s.allHolidayDates()
+	if err != nil {
+		return err
+	}
+	overrides, err := s.allParticipantOverrides()
+	if err != nil {
+		return err
+	}
 
 	for _, standup := range standups {
-		if len(standup.Participants) == 0 {
-            continue
-        }
+		holidays := holidaysByStandup[standup.ID]
 
-		timeStr := standup.Time
-		if timeStr == "" {
-			timeStr = "09:00"
-		}
+		// Standup-wide events aren't persisted; anything that fell inside the catch-up
+		// window is re-queued and deduplicated by the record it produces.
+		entries = append(entries, s.standupEntries(standup, now.Add(-s.catchUpWindow()), holidays)...)
 
-		var targetHour, targetMinute int
-		_, err := fmt.Sscanf(timeStr, "%d:%d", &targetHour, &targetMinute)
-		if err != nil {
-			log.Printf("Invalid time format for standup %s: %s", standup.Name, timeStr)
-			continue
-		}
+		for _, user := range standup.Participants {
+			key := scheduleKey(standup.ID, user.UserID)
+			schedule, exists := scheduleMap[key]
+			delete(scheduleMap, key)
+
+			if !exists || schedule.UpdatedAt.Before(standup.UpdatedAt) || schedule.UpdatedAt.Before(user.UpdatedAt) {
+				if !exists {
+					schedule = &models.StandupSchedule{StandupID: standup.ID, UserID: user.UserID}
+				}
+				loc := scheduleLocation(tzCache, standup, user)
+				personal := withOverride(standup, overrides[key])
+				schedule.NextFireAt = nextFireTime(personal, loc, currentMinute, holidays)
+				if err := s.DB.Save(schedule).Error; err != nil {
+					log.Printf("Error saving schedule for %s in standup %d: %v", user.UserID, standup.ID, err)
+					continue
+				}
+			}
 
-		activeDays := standup.Days
-		if activeDays == "" {
-			activeDays = "Monday,Tuesday,Wednesday,Thursday,Friday"
+			entries = append(entries,
+				scheduleEntry{Kind: entryPrompt, StandupID: standup.ID, UserID: user.UserID,
+					FireAt: schedule.NextFireAt},
+				scheduleEntry{Kind: entryReminder, StandupID: standup.ID, UserID: user.UserID,
+					FireAt: nextReminderAt(standup, *schedule)})
 		}
+	}
 
-		for _, user := range standup.Participants {
-			tz := user.Timezone
-			if tz == "" {
-				tz = "UTC"
+	for _, orphan := range scheduleMap {
+		s.DB.Delete(orphan)
+	}
+
+	s.scheduler().Load(entries)
+	return nil
+}
+
+func (s *StandupService) CheckAndTriggerStandups() {
+	now := time.Now().UTC()
+	due := s.scheduler().PopDue(now)
+	if len(due) == 0 {
+		return
+	}
+
+	standupCache := make(map[uint]*models.Standup)
+	tzCache := make(map[string]*time.Location)
+
+	for _, entry := range due {
+		standup, cached := standupCache[entry.StandupID]
+		if !cached {
+			var st models.Standup
+			if err := s.DB.First(&st, entry.StandupID).Error; err == nil {
+				standup = &st
 			}
+			standupCache[entry.StandupID] = standup
+		}
 
-			loc, exists := tzCache[tz]
-            if !exists {
-                var err error
-                loc, err = time.LoadLocation(tz)
-                if err != nil {
-                    log.Printf("⚠️ Warning: Unknown timezone '%s' for user %s. Falling back to UTC.", tz, user.UserID)
-                    loc = time.UTC
-                }
-                tzCache[tz] = loc
-            }
-
-			userLocalTime := time.Now().In(loc)
-
-			currentDay := userLocalTime.Weekday().String()
-			if !strings.Contains(activeDays, currentDay) {
+		if entry.UserID == "" {
+			if standup == nil {
 				continue
 			}
+			switch entry.Kind {
+			case entryDeadline:
+				s.fireDeadline(*standup, entry.FireAt, now)
+			case entryDigest:
+				s.fireDigest(*standup, entry.FireAt, now)
+			case entryResume:
+				s.fireResume(*standup, now)
+			}
+			continue
+		}
 
-			if userLocalTime.Hour() == targetHour && userLocalTime.Minute() == targetMinute {
-				today := userLocalTime.Format("2006-01-02")
+		var user models.UserProfile
+		if standup == nil || s.DB.Where("user_id = ?", entry.UserID).First(&user).Error != nil {
+			s.DB.Where("standup_id = ? AND user_id = ?", entry.StandupID, entry.UserID).
+				Delete(&models.StandupSchedule{})
+			continue
+		}
 
-				var history models.StandupHistory
-				result := s.DB.Where("user_id = ? AND standup_id = ? AND date = ?",
-					user.UserID, standup.ID, today).First(&history)
+		var schedule models.StandupSchedule
+		if err := s.DB.Where("standup_id = ? AND user_id = ?", standup.ID, user.UserID).
+			First(&schedule).Error; err != nil {
+			continue
+		}
 
-				if result.Error != nil {
-					log.Printf("🔔 Pinging %s for standup: %s", user.UserID, standup.Name)
-					
-					channel, err := s.Session.UserChannelCreate(user.UserID)
-					if err == nil {
-						s.Session.ChannelMessageSend(channel.ID,
-							fmt.Sprintf("🔔 **Hey!** It's time for your **%s** standup.", standup.Name))
+		loc := loadLocation(tzCache, user.Timezone, user.UserID)
 
-						s.TriggerFunc(s.Session, user.UserID, standup.GuildID, "", standup.ID)
-					}
-				}
-			}
+		switch entry.Kind {
+		case entryPrompt:
+			s.firePrompt(*standup, user, &schedule, scheduleLocation(tzCache, *standup, user), loc, now)
+		case entryReminder:
+			s.fireReminder(*standup, user, &schedule, loc, now)
 		}
 	}
 }
+
+func (s *StandupService) catchUpWindow() time.Duration {
+	if s.CatchUpWindow <= 0 {
+		return DefaultCatchUpWindow
+	}
+	return s.CatchUpWindow
+}
+
+// firePrompt advances the participant's schedule in fireLoc, the clock it follows, and
+// prompts them for the day it fell on in their own timezone, userLoc.
+func (s *StandupService) firePrompt(standup models.Standup, user models.UserProfile,
+	schedule *models.StandupSchedule, fireLoc, userLoc *time.Location, now time.Time) {
+
+	dueAt := schedule.NextFireAt
+	if dueAt.IsZero() || dueAt.After(now) {
+		// The row was rescheduled after this entry was queued.
+		s.scheduler().Set(entryPrompt, standup.ID, user.UserID, dueAt)
+		return
+	}
+
+	lateBy := now.Sub(dueAt)
+	withinWindow := lateBy <= s.catchUpWindow()
+
+	personal := withOverride(standup, s.ParticipantOverride(standup.ID, user.UserID))
+	schedule.NextFireAt = nextFireTime(personal, fireLoc, now, s.holidayDates(standup.ID))
+	if withinWindow {
+		schedule.LastFiredAt = &dueAt
+		schedule.RemindersSent = 0
+	}
+	if err := s.DB.Save(schedule).Error; err != nil {
+		log.Printf("Error advancing schedule for %s in standup %d: %v", user.UserID, standup.ID, err)
+	}
+	s.scheduler().Set(entryPrompt, standup.ID, user.UserID, schedule.NextFireAt)
+	s.scheduler().Set(entryReminder, standup.ID, user.UserID, nextReminderAt(standup, *schedule))
+
+	if !withinWindow {
+		log.Printf("⏭️ Missed %s standup for %s by %s (outside catch-up window)",
+			standup.Name, user.UserID, lateBy.Round(time.Minute))
+		return
+	}
+
+	s.promptParticipant(standup, user, dueAt.In(userLoc), lateBy)
+}
+
+func (s *StandupService) fireReminder(standup models.Standup, user models.UserProfile,
+	schedule *models.StandupSchedule, loc *time.Location, now time.Time) {
+
+	remindAt := nextReminderAt(standup, *schedule)
+	if remindAt.IsZero() || remindAt.After(now) {
+		s.scheduler().Set(entryReminder, standup.ID, user.UserID, remindAt)
+		return
+	}
+
+	schedule.RemindersSent++
+	if err := s.DB.Save(schedule).Error; err != nil {
+		log.Printf("Error recording reminder for %s in standup %d: %v", user.UserID, standup.ID, err)
+	}
+	s.scheduler().Set(entryReminder, standup.ID, user.UserID, nextReminderAt(standup, *schedule))
+
+	if now.Sub(remindAt) > s.catchUpWindow() {
+		return
+	}
+
+	// Reminders belong to the day of the prompt they follow up on.
+	promptDate := schedule.LastFiredAt.In(loc).Format("2006-01-02")
+
+	var history models.StandupHistory
+	if err := s.DB.Where("user_id = ? AND standup_id = ? AND date = ?",
+		user.UserID, standup.ID, promptDate).First(&history).Error; err == nil {
+		return
+	}
+
+	// The range may have been registered after the day's prompt already went out.
+	if s.IsOutOfOffice(user.UserID, standup.ID, promptDate) {
+		return
+	}
+
+	event := fmt.Sprintf("reminder-%d", schedule.RemindersSent)
+	if s.Redis != nil && !store.MarkTriggered(s.Redis, event, user.UserID, standup.ID, promptDate) {
+		return
+	}
+
+	log.Printf("⏰ Reminder %d to %s for standup: %s", schedule.RemindersSent, user.UserID, standup.Name)
+	if s.ReminderFunc != nil {
+		s.ReminderFunc(s.Session, user.UserID, standup.ID, schedule.RemindersSent)
+	}
+}
+
+func (s *StandupService) promptParticipant(standup models.Standup, user models.UserProfile,
+	scheduledAt time.Time, lateBy time.Duration) {
+
+	today := scheduledAt.Format("2006-01-02")
+
+	var history models.StandupHistory
+	result := s.DB.Where("user_id = ? AND standup_id = ? AND date = ?",
+		user.UserID, standup.ID, today).First(&history)
+
+	if result.Error == nil {
+		return
+	}
+
+	if s.Redis != nil && !store.MarkTriggered(s.Redis, entryPrompt, user.UserID, standup.ID, today) {
+		return
+	}
+
+	if s.IsOutOfOffice(user.UserID, standup.ID, today) {
+		s.recordOutOfOffice(standup, user.UserID, today)
+		return
+	}
+
+	isLate := lateBy >= time.Minute
+	if isLate {
+		log.Printf("🔔 Pinging %s for standup: %s (late by %s)", user.UserID, standup.Name, lateBy.Round(time.Minute))
+	} else {
+		log.Printf("🔔 Pinging %s for standup: %s", user.UserID, standup.Name)
+	}
+
+	channel, err := s.Session.UserChannelCreate(user.UserID)
+	if err != nil {
+		return
+	}
+
+	msg := fmt.Sprintf("🔔 **Hey!** It's time for your **%s** standup.", standup.Name)
+	if isLate {
+		msg = fmt.Sprintf("🔔 **Hey!** Your **%s** standup was due at **%s**. Sorry, this reminder is running late!",
+			standup.Name, scheduledAt.Format("15:04"))
+	}
+	s.Session.ChannelMessageSend(channel.ID, msg)
+
+	s.TriggerFunc(s.Session, user.UserID, standup.GuildID, "", standup.ID)
+}
+
+func scheduleKey(standupID uint, userID string) string {
+	return fmt.Sprintf("%d:%s", standupID, userID)
+}
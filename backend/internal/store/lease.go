@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const triggerDedupTTL = 48 * time.Hour
+
+var renewLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// AcquireLease takes the lease if it is free, or extends it if owner already holds it.
+// It reports whether owner holds the lease afterwards.
+func AcquireLease(rdb *redis.Client, key, owner string, ttl time.Duration) bool {
+	ctx := context.Background()
+
+	acquired, err := rdb.SetNX(ctx, "lease:"+key, owner, ttl).Result()
+	if err != nil {
+		log.Printf("Warning: failed to acquire lease %s: %v", key, err)
+		return false
+	}
+	if acquired {
+		return true
+	}
+
+	renewed, err := renewLeaseScript.Run(ctx, rdb, []string{"lease:" + key}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		log.Printf("Warning: failed to renew lease %s: %v", key, err)
+		return false
+	}
+	return renewed == 1
+}
+
+func ReleaseLease(rdb *redis.Client, key, owner string) {
+	releaseLeaseScript.Run(context.Background(), rdb, []string{"lease:" + key}, owner)
+}
+
+// MarkTriggered records that a standup event (the prompt, a reminder, ...) went out for a
+// user on a given local date. It returns false if another tick or replica already did.
+func MarkTriggered(rdb *redis.Client, event, userID string, standupID uint, date string) bool {
+	key := fmt.Sprintf("triggered:%s:%d:%s:%s", event, standupID, userID, date)
+
+	ok, err := rdb.SetNX(context.Background(), key, time.Now().Unix(), triggerDedupTTL).Result()
+	if err != nil {
+		log.Printf("Warning: failed to record trigger %s: %v", key, err)
+		return false
+	}
+	return ok
+}
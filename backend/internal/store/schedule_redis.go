@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const scheduleChannel = "schedule_invalidations"
+
+// PublishScheduleChanges tells every replica which schedule rows were rewritten so the
+// scheduler leader can reload them from the database.
+func PublishScheduleChanges(rdb *redis.Client, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	data, _ := json.Marshal(keys)
+	if err := rdb.Publish(context.Background(), scheduleChannel, data).Err(); err != nil {
+		log.Printf("Warning: failed to publish schedule changes: %v", err)
+	}
+}
+
+func SubscribeScheduleChanges(rdb *redis.Client, handler func(keys []string)) {
+	pubsub := rdb.Subscribe(context.Background(), scheduleChannel)
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			var keys []string
+			if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+				log.Printf("Warning: malformed schedule change payload: %v", err)
+				continue
+			}
+			handler(keys)
+		}
+	}()
+}
@@ -25,3 +25,21 @@ func GetState(rdb *redis.Client, userID string) (*models.StandupState, error) {
 
 	return &state, nil
 }
+
+// SetChatSession records which standup the user's next DM answers in chat mode.
+func SetChatSession(rdb *redis.Client, userID string, standupID uint) {
+	rdb.Set(context.Background(), "chat:"+userID, standupID, 24*time.Hour)
+}
+
+// GetChatSession returns the standup the user is answering by DM, or 0 if none.
+func GetChatSession(rdb *redis.Client, userID string) uint {
+	standupID, err := rdb.Get(context.Background(), "chat:"+userID).Uint64()
+	if err != nil {
+		return 0
+	}
+	return uint(standupID)
+}
+
+func ClearChatSession(rdb *redis.Client, userID string) {
+	rdb.Del(context.Background(), "chat:"+userID)
+}
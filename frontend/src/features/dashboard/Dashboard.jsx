@@ -143,7 +143,7 @@ export default function Dashboard() {
       ))
     ) : (
       <div className="flex-1 flex items-center justify-center h-22 text-[#99AAB5] text-sm bg-[#2b2d31]/50 rounded-xl border border-dashed border-[#3f4147] min-w-[320px]">
-        No updates or blockers reported today.
+        No open blockers.
       </div>
     );
   };
@@ -224,7 +224,7 @@ export default function Dashboard() {
                 className={`w-1.5 h-1.5 rounded-full animate-pulse ${viewMode === "standups" ? "bg-[#da373c]" : "bg-[#38bdf8]"}`}
               ></span>
               {viewMode === "standups"
-                ? "Latest Standup Updates"
+                ? "Open Blockers"
                 : "Recent Polls Feed"}
             </h3>
             <div className="flex flex-row gap-4 overflow-x-auto pb-3 pt-1 snap-x scroll-smooth custom-scrollbar">
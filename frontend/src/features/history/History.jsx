@@ -105,9 +105,15 @@ export default function History() {
       const headers = ["Date", "User", "Status", ...questions];
 
       const rows = historyData.map((row) => {
+        const isOOO = row.answers.length > 0 && row.answers[0] === "OOO";
         const isSkipped =
-          row.answers.length > 0 && row.answers[0] === "Skipped / OOO";
-        const statusStr = isSkipped ? "Skipped" : "Submitted";
+          isOOO ||
+          (row.answers.length > 0 && row.answers[0] === "Skipped / OOO");
+        const statusStr = isOOO
+          ? "Out of Office"
+          : isSkipped
+            ? "Skipped"
+            : "Submitted";
         const userStr = `"${row.user_name}"`;
         const dateStr = `"${row.date}"`;
         const answersStr = row.answers.map((a) => `"${a.replace(/"/g, '""')}"`);
@@ -337,9 +343,12 @@ export default function History() {
                       </tr>
                     ) : viewMode === "standups" ? (
                       historyData.map((log, index) => {
+                        const isOOO =
+                          log.answers.length > 0 && log.answers[0] === "OOO";
                         const isSkipped =
-                          log.answers.length > 0 &&
-                          log.answers[0] === "Skipped / OOO";
+                          isOOO ||
+                          (log.answers.length > 0 &&
+                            log.answers[0] === "Skipped / OOO");
                         const totalQuestionCols = displayQuestions
                           ? displayQuestions.length
                           : 1;
@@ -387,7 +396,7 @@ export default function History() {
                                       d="M10 14l2-2m0 0l2-2m-2 2l-2-2m2 2l2 2m7-2a9 9 0 11-18 0 9 9 0 0118 0z"
                                     ></path>
                                   </svg>
-                                  Skipped
+                                  {isOOO ? "Out of Office" : "Skipped"}
                                 </span>
                               ) : (
                                 <span
@@ -12,6 +12,8 @@ import {
   useToggleMemberMutation,
   useUpdateStandupMutation,
   useDeleteStandupMutation,
+  usePauseStandupMutation,
+  useResumeStandupMutation,
 } from "../../store/apiSlice";
 
 export default function ManageStandup() {
@@ -35,6 +37,15 @@ export default function ManageStandup() {
   const [updateStandupMutation, { isLoading: isSaving }] =
     useUpdateStandupMutation();
   const [deleteStandupMutation] = useDeleteStandupMutation();
+  const [pauseStandupMutation, { isLoading: isPausing }] =
+    usePauseStandupMutation();
+  const [resumeStandupMutation, { isLoading: isResuming }] =
+    useResumeStandupMutation();
+
+  const [showPauseForm, setShowPauseForm] = useState(false);
+  const [pauseReason, setPauseReason] = useState("");
+  const [resumeOn, setResumeOn] = useState("");
+  const isPaused = !!standup?.paused_at;
 
   const toggleMember = async (userId, isCurrentlyMember) => {
     try {
@@ -72,6 +83,31 @@ export default function ManageStandup() {
     }
   };
 
+  const pauseStandup = async () => {
+    try {
+      await pauseStandupMutation({
+        standupId: id,
+        reason: pauseReason,
+        resumeOn,
+      }).unwrap();
+      setShowPauseForm(false);
+      setPauseReason("");
+      setResumeOn("");
+    } catch (err) {
+      console.error("Failed to pause standup", err);
+      alert(err?.data || "Failed to pause standup.");
+    }
+  };
+
+  const resumeStandup = async () => {
+    try {
+      await resumeStandupMutation(id).unwrap();
+    } catch (err) {
+      console.error("Failed to resume standup", err);
+      alert(err?.data || "Failed to resume standup.");
+    }
+  };
+
   const tabs = [
     { id: "members", label: "👥 Members" },
     { id: "settings", label: "⚙️ Settings" },
@@ -95,8 +131,71 @@ export default function ManageStandup() {
             <h1 className="text-2xl font-extrabold truncate">
               {standup?.name || "Loading..."}
             </h1>
+            {standup && (
+              <div className="ml-auto flex items-center gap-3 shrink-0">
+                {isPaused && (
+                  <span
+                    className="text-xs font-semibold text-[#faa61a] bg-[#faa61a]/10 px-2 py-1 rounded-md"
+                    title={standup.pause_reason || undefined}
+                  >
+                    ⏸️ Paused
+                    {standup.resume_on
+                      ? ` until ${standup.resume_on}`
+                      : " until resumed"}
+                  </span>
+                )}
+                <button
+                  onClick={() =>
+                    isPaused ? resumeStandup() : setShowPauseForm(!showPauseForm)
+                  }
+                  disabled={isResuming}
+                  className="text-sm font-semibold bg-[#2b2d31] px-3 py-1.5 rounded-md border 
+                  border-[#1e1f22] text-[#dcddde] hover:text-white transition-colors disabled:opacity-50"
+                >
+                  {isPaused ? "▶️ Resume" : "⏸️ Pause"}
+                </button>
+              </div>
+            )}
           </div>
 
+          {showPauseForm && !isPaused && (
+            <div className="flex flex-wrap items-end gap-3 mb-6 bg-[#2b2d31] p-4 rounded-md border border-[#1e1f22] animate-fade-in">
+              <div className="flex-1 min-w-48">
+                <label className="block text-xs font-bold text-[#99AAB5] uppercase mb-1">
+                  Reason (optional)
+                </label>
+                <input
+                  type="text"
+                  value={pauseReason}
+                  onChange={(e) => setPauseReason(e.target.value)}
+                  placeholder="e.g. Team offsite"
+                  className="w-full bg-[#1e1f22] text-sm text-white px-3 py-2 rounded-md outline-none 
+                  border border-[#3f4147] focus:border-[#5865F2] placeholder-[#99AAB5]"
+                />
+              </div>
+              <div>
+                <label className="block text-xs font-bold text-[#99AAB5] uppercase mb-1">
+                  Resume on (optional)
+                </label>
+                <input
+                  type="date"
+                  value={resumeOn}
+                  onChange={(e) => setResumeOn(e.target.value)}
+                  className="bg-[#1e1f22] text-sm text-white px-3 py-2 rounded-md outline-none 
+                  border border-[#3f4147] focus:border-[#5865F2]"
+                />
+              </div>
+              <button
+                onClick={pauseStandup}
+                disabled={isPausing}
+                className="bg-[#5865F2] hover:bg-[#4752C4] text-white text-sm font-semibold px-4 py-2 
+                rounded-md transition-colors disabled:opacity-50"
+              >
+                {isPausing ? "Pausing..." : "Pause Standup"}
+              </button>
+            </div>
+          )}
+
           <div className="flex gap-6 mt-2">
             {tabs.map((tab) => (
               <button
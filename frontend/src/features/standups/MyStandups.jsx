@@ -220,6 +220,17 @@ export default function MyStandups() {
                           🕒 {s.time}
                         </span>
                       </div>
+                      {s.paused && (
+                        <div className="flex items-center justify-between">
+                          <span className="text-[#99AAB5]">Status</span>
+                          <span
+                            className="text-[#faa61a] bg-[#faa61a]/10 px-2 py-1 rounded-md text-xs font-semibold"
+                            title={s.pause_reason || undefined}
+                          >
+                            ⏸️ Paused{s.resume_on ? ` until ${s.resume_on}` : ""}
+                          </span>
+                        </div>
+                      )}
                       <div className="flex items-center justify-between">
                         <span className="text-[#99AAB5]">Channel</span>
                         <span className="text-[#43b581] font-bold flex items-center gap-1">
@@ -4,6 +4,7 @@ import TimePicker from "../../../components/TimePicker";
 import {
   useGetUserGuildsQuery,
   useGetGuildChannelsQuery,
+  useGetTemplatesQuery,
   useCreateStandupMutation,
 } from "../../../store/apiSlice";
 
@@ -17,45 +18,15 @@ const DAYS_OF_WEEK = [
   "Sunday",
 ];
 
-const STANDUP_TEMPLATES = [
-  {
-    id: "daily_scrum",
-    name: "Daily Agile Scrum",
-    questions: [
-      "What did you accomplish yesterday?",
-      "What will you do today?",
-      "Are you stuck anywhere?",
-    ],
-  },
-  {
-    id: "weekly_retro",
-    name: "Weekly Retrospective",
-    questions: [
-      "What went well this week?",
-      "What could have gone better?",
-      "What are our action items for next week?",
-    ],
-  },
-  {
-    id: "mental_health",
-    name: "Mental Health & Workload Check",
-    questions: [
-      "How are you feeling today (1-10)?",
-      "How is your current workload?",
-      "Is there anything blocking you or causing stress?",
-    ],
-  },
-  {
-    id: "custom",
-    name: "Custom (Start from scratch)",
-    questions: [""],
-  },
-];
+// Built-in, server and personal templates come from the API, the same library the
+// bot's /create-standup offers. "custom" starts from a blank question list.
+const CUSTOM_TEMPLATE = "custom";
+const DEFAULT_TEMPLATE = "builtin:daily-scrum";
 
 export default function CreateStandupModal({ isOpen, onClose }) {
   const dropdownRef = useRef(null);
   const [currentStep, setCurrentStep] = useState(1);
-  const [selectedTemplate, setSelectedTemplate] = useState("daily_scrum");
+  const [selectedTemplate, setSelectedTemplate] = useState(DEFAULT_TEMPLATE);
 
   const [formData, setFormData] = useState({
     name: "",
@@ -63,7 +34,7 @@ export default function CreateStandupModal({ isOpen, onClose }) {
     days: ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"],
     guild_id: "",
     report_channel_id: "",
-    questions: [...STANDUP_TEMPLATES[0].questions],
+    questions: [""],
   });
 
   const [isChannelDropdownOpen, setIsChannelDropdownOpen] = useState(false);
@@ -74,8 +45,13 @@ export default function CreateStandupModal({ isOpen, onClose }) {
   } = useGetUserGuildsQuery(undefined, { skip: !isOpen });
   const { data: channels = [], isFetching: isFetchingChannels } =
     useGetGuildChannelsQuery(formData.guild_id, { skip: !formData.guild_id });
+  const { data: templates = [] } = useGetTemplatesQuery(formData.guild_id, {
+    skip: !isOpen,
+  });
   const [createStandup, { isLoading: isCreating }] = useCreateStandupMutation();
 
+  const activeTemplate = templates.find((t) => templateRef(t) === selectedTemplate);
+
   useEffect(() => {
     function handleClickOutside(event) {
       if (dropdownRef.current && !dropdownRef.current.contains(event.target)) {
@@ -89,30 +65,48 @@ export default function CreateStandupModal({ isOpen, onClose }) {
   useEffect(() => {
     if (!isOpen) {
       setCurrentStep(1);
-      setSelectedTemplate("daily_scrum");
+      setSelectedTemplate(DEFAULT_TEMPLATE);
       setFormData({
         name: "",
         time: "09:00",
         days: ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"],
         guild_id: "",
         report_channel_id: "",
-        questions: [...STANDUP_TEMPLATES[0].questions],
+        questions: [""],
       });
     }
   }, [isOpen]);
 
+  const applyTemplate = (template, fillName) => {
+    setFormData((prev) => ({
+      ...prev,
+      questions: [...template.questions],
+      time: template.time || prev.time,
+      days: template.days ? template.days.split(",") : prev.days,
+      // UX Bonus: Auto-fill the name if they haven't typed one yet
+      name: fillName && prev.name === "" ? template.name : prev.name,
+    }));
+  };
+
+  // Prefill the default template's questions once the library has loaded.
+  useEffect(() => {
+    const blank = formData.questions.every((q) => !q);
+    if (selectedTemplate === DEFAULT_TEMPLATE && activeTemplate && blank) {
+      applyTemplate(activeTemplate, false);
+    }
+  }, [activeTemplate]);
+
   const handleTemplateChange = (e) => {
     const tId = e.target.value;
     setSelectedTemplate(tId);
-    
-    const template = STANDUP_TEMPLATES.find((t) => t.id === tId);
+
+    if (tId === CUSTOM_TEMPLATE) {
+      setFormData((prev) => ({ ...prev, questions: [""] }));
+      return;
+    }
+    const template = templates.find((t) => templateRef(t) === tId);
     if (template) {
-      setFormData((prev) => ({
-        ...prev,
-        questions: [...template.questions],
-        // UX Bonus: Auto-fill the name if they haven't typed one yet
-        name: prev.name === "" && tId !== "custom" ? template.name : prev.name,
-      }));
+      applyTemplate(template, true);
     }
   };
 
@@ -176,12 +170,22 @@ export default function CreateStandupModal({ isOpen, onClose }) {
       return;
     }
 
+    const payload = {
+      ...formData,
+      questions: cleanedQuestions,
+      days: formData.days.join(","),
+    };
+    if (activeTemplate) {
+      // The template supplies its report mode and cadence; its answer types only
+      // still apply while the questions are the ones it came with.
+      payload.template = selectedTemplate;
+      if (JSON.stringify(cleanedQuestions) === JSON.stringify(activeTemplate.questions)) {
+        payload.question_specs = activeTemplate.question_specs;
+      }
+    }
+
     try {
-      await createStandup({
-        ...formData,
-        questions: cleanedQuestions,
-        days: formData.days.join(","),
-      }).unwrap();
+      await createStandup(payload).unwrap();
       onClose();
     } catch (err) {
       console.error("Creation failed", err);
@@ -263,11 +267,13 @@ export default function CreateStandupModal({ isOpen, onClose }) {
                     onChange={handleTemplateChange}
                     className="w-full bg-[#1e1f22] text-sm text-white px-3 py-2.5 rounded-md outline-none border border-[#1e1f22] focus:border-[#5865F2] cursor-pointer"
                   >
-                    {STANDUP_TEMPLATES.map((t) => (
-                      <option key={t.id} value={t.id}>
+                    {templates.map((t) => (
+                      <option key={templateRef(t)} value={templateRef(t)}>
                         {t.name}
+                        {t.scope === "guild" ? " (Server)" : t.scope === "personal" ? " (Yours)" : ""}
                       </option>
                     ))}
+                    <option value={CUSTOM_TEMPLATE}>Custom (Start from scratch)</option>
                   </select>
                 </div>
 
@@ -565,4 +571,9 @@ export default function CreateStandupModal({ isOpen, onClose }) {
       </div>
     </div>
   );
-}
\ No newline at end of file
+}
+
+// templateRef is how the API refers to a template: "builtin:<key>" or the stored ID.
+function templateRef(template) {
+  return template.scope === "builtin" ? `builtin:${template.key}` : String(template.id);
+}
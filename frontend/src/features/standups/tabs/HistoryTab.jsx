@@ -119,8 +119,11 @@ export default function HistoryTab({ standup, guildMembers }) {
 
                 {/* Body: Questions and Answers */}
                 <div className="p-5 space-y-4">
-                  {log.answers.map((answer, i) => {
+                  {(log.entries || log.answers).map((entry, i) => {
+                    // Entries carry the question as it was asked that day.
+                    const answer = log.entries ? entry.answer : entry;
                     const question =
+                      (log.entries && entry.question) ||
                       standup?.questions?.[i] ||
                       standup?.Questions?.[i] ||
                       `Question ${i + 1}`;
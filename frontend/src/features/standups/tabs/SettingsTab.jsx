@@ -25,6 +25,7 @@ export default function SettingsTab({
     days: ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"], // Default days
     report_channel_id: "",
     questions: [],
+    question_ids: [],
   });
 
   useEffect(() => {
@@ -36,13 +37,19 @@ export default function SettingsTab({
         parsedDays = daysStr.split(",").map((d) => d.trim());
       }
 
+      // Each question's ID travels with it, so rewording or moving a question keeps
+      // its answer type and its link to past reports.
+      const questions = standup.questions || standup.Questions || [];
+      const specs = standup.question_specs || [];
+
       setFormData({
         name: standup.name || standup.Name || "",
         time: standup.time || standup.Time || "09:00",
         days: parsedDays,
         report_channel_id:
           standup.report_channel_id || standup.ReportChannelID || "",
-        questions: standup.questions || standup.Questions || [],
+        questions,
+        question_ids: questions.map((_, i) => specs[i]?.id || ""),
       });
     }
   }, [standup]);
@@ -63,28 +70,39 @@ export default function SettingsTab({
   };
 
   const addQuestion = () => {
-    setFormData({ ...formData, questions: [...formData.questions, ""] });
+    setFormData({
+      ...formData,
+      questions: [...formData.questions, ""],
+      question_ids: [...formData.question_ids, ""],
+    });
   };
 
   const removeQuestion = (index) => {
     if (formData.questions.length <= 1) return;
-    const newQuestions = formData.questions.filter((_, i) => i !== index);
-    setFormData({ ...formData, questions: newQuestions });
+    setFormData({
+      ...formData,
+      questions: formData.questions.filter((_, i) => i !== index),
+      question_ids: formData.question_ids.filter((_, i) => i !== index),
+    });
   };
 
   const onDragEnd = (result) => {
     if (!result.destination) return;
     const items = Array.from(formData.questions);
+    const ids = Array.from(formData.question_ids);
     const [reorderedItem] = items.splice(result.source.index, 1);
+    const [reorderedID] = ids.splice(result.source.index, 1);
     items.splice(result.destination.index, 0, reorderedItem);
-    setFormData({ ...formData, questions: items });
+    ids.splice(result.destination.index, 0, reorderedID);
+    setFormData({ ...formData, questions: items, question_ids: ids });
   };
 
   const handleSubmit = (e) => {
     e.preventDefault();
-    const cleanedQuestions = formData.questions
-      .map((q) => q.trim())
-      .filter((q) => q.length > 0);
+    const kept = formData.questions
+      .map((q, i) => ({ text: q.trim(), id: formData.question_ids[i] || "" }))
+      .filter((q) => q.text.length > 0);
+    const cleanedQuestions = kept.map((q) => q.text);
 
     if (cleanedQuestions.length === 0) {
       alert("You must have at least one valid question.");
@@ -100,6 +118,7 @@ export default function SettingsTab({
     onSave({
       ...formData,
       questions: cleanedQuestions,
+      question_ids: kept.map((q) => q.id),
       days: formData.days.join(","),
     });
   };
@@ -41,6 +41,9 @@ export const asyncFlowApi = createApi({
         `managed-standups?filter=${filter}&page=${page}&limit=${limit}&search=${encodeURIComponent(search)}&guild_id=${guild_id}`,
       providesTags: ["ManagedStandups"],
     }),
+    getTemplates: builder.query({
+      query: (guildId = "") => `templates?guild_id=${guildId}`,
+    }),
     getUserGuilds: builder.query({
       query: () => "user-guilds",
     }),
@@ -85,6 +88,32 @@ export const asyncFlowApi = createApi({
       }),
       invalidatesTags: ["ManagedStandups"],
     }),
+    pauseStandup: builder.mutation({
+      query: ({ standupId, reason = "", resumeOn = "" }) => ({
+        url: `standups/pause`,
+        method: "POST",
+        body: {
+          standup_id: parseInt(standupId),
+          reason,
+          resume_on: resumeOn,
+        },
+      }),
+      invalidatesTags: (result, error, arg) => [
+        { type: "Standup", id: arg.standupId },
+        "ManagedStandups",
+      ],
+    }),
+    resumeStandup: builder.mutation({
+      query: (standupId) => ({
+        url: `standups/resume`,
+        method: "POST",
+        body: { standup_id: parseInt(standupId) },
+      }),
+      invalidatesTags: (result, error, arg) => [
+        { type: "Standup", id: arg },
+        "ManagedStandups",
+      ],
+    }),
 
     getManagedPolls: builder.query({
       query: ({ filter, page, limit = 12, search = "", guild_id = "" }) =>
@@ -145,6 +174,7 @@ export const {
   useGetUserGuildsQuery,
   useGetGuildMembersQuery,
   useGetGuildChannelsQuery,
+  useGetTemplatesQuery,
   useGetHistoryQuery,
   useGetDashboardStatsQuery,
   useGetPollDashboardStatsQuery,
@@ -152,6 +182,8 @@ export const {
   useToggleMemberMutation,
   useUpdateStandupMutation,
   useDeleteStandupMutation,
+  usePauseStandupMutation,
+  useResumeStandupMutation,
   useGetManagedStandupsQuery,
 
   useGetManagedPollsQuery,